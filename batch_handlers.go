@@ -0,0 +1,148 @@
+package main
+
+import (
+  "bytes"
+  "errors"
+  "fmt"
+  "net/http"
+  "net/http/httptest"
+  "encoding/json"
+  "regexp"
+  "strconv"
+  "strings"
+)
+
+// a string parameter of the form "$<index>" or "$<index>.<field>.<field>..."
+// is replaced by (part of) the result of an earlier item, e.g. "$0.curvepoint"
+// or "$2.number.v"; a leading "$$" escapes a literal "$"
+var batchRefPattern = regexp.MustCompile(`^\$([0-9]+)((\.[A-Za-z0-9_]+)*)$`)
+
+const MaxBatchItems = 256
+
+func NewBatchHandler(router http.Handler) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var batchItems []*BatchItem
+    err := ReadContentsIntoStruct(r, &batchItems)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    if len(batchItems) > MaxBatchItems {
+      encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Number of operations must be at most %d", MaxBatchItems)}})
+      return
+    }
+    results := make([]json.RawMessage, len(batchItems))
+    decoded := make([]interface{}, len(batchItems))
+    for i, item := range batchItems {
+      result, err := RunBatchItem(router, item, decoded[:i])
+      if err != nil {
+        result, _ = json.Marshal(Response{Err: &Error{Msg: err.Error()}})
+      }
+      results[i] = result
+      json.Unmarshal(result, &decoded[i])
+    }
+    encoder.Encode(Response{Results: results})
+  }
+}
+
+func RunBatchItem(router http.Handler, item *BatchItem, previous []interface{}) (json.RawMessage, error) {
+  if item == nil || item.Op == "" {
+    return nil, errors.New("Missing op")
+  }
+  if !strings.HasPrefix(item.Op, "/") {
+    return nil, errors.New("Op must start with /")
+  }
+  if strings.HasPrefix(item.Op, "/batch") {
+    return nil, errors.New("Batches cannot be nested")
+  }
+  method := item.Method
+  var body []byte
+  if len(item.Params) > 0 {
+    var params interface{}
+    err := json.Unmarshal(item.Params, &params)
+    if err != nil {
+      return nil, err
+    }
+    params, err = ResolveBatchRefs(params, previous)
+    if err != nil {
+      return nil, err
+    }
+    body, err = json.Marshal(params)
+    if err != nil {
+      return nil, err
+    }
+    if method == "" { method = "POST" }
+  }
+  if method == "" { method = "GET" }
+  req, err := http.NewRequest(method, item.Op, bytes.NewReader(body))
+  if err != nil {
+    return nil, err
+  }
+  req.Header.Set("Content-Type", "application/json")
+  rec := httptest.NewRecorder()
+  router.ServeHTTP(rec, req)
+  result := bytes.TrimSpace(rec.Body.Bytes())
+  if rec.Code != http.StatusOK || !json.Valid(result) {
+    return nil, fmt.Errorf("%s %s failed: %d %s", method, item.Op, rec.Code, http.StatusText(rec.Code))
+  }
+  return json.RawMessage(result), nil
+}
+
+func ResolveBatchRefs(params interface{}, previous []interface{}) (interface{}, error) {
+  switch v := params.(type) {
+  case map[string]interface{}:
+    for key, value := range v {
+      resolved, err := ResolveBatchRefs(value, previous)
+      if err != nil {
+        return nil, err
+      }
+      v[key] = resolved
+    }
+    return v, nil
+  case []interface{}:
+    for i, value := range v {
+      resolved, err := ResolveBatchRefs(value, previous)
+      if err != nil {
+        return nil, err
+      }
+      v[i] = resolved
+    }
+    return v, nil
+  case string:
+    if strings.HasPrefix(v, "$$") {
+      return v[1:], nil
+    }
+    match := batchRefPattern.FindStringSubmatch(v)
+    if match == nil {
+      return v, nil
+    }
+    index, err := strconv.Atoi(match[1])
+    if err != nil || index >= len(previous) {
+      return nil, fmt.Errorf("Reference %s does not point to an earlier item", v)
+    }
+    var value interface{} = previous[index]
+    if result, ok := value.(map[string]interface{}); ok && result["error"] != nil {
+      return nil, fmt.Errorf("Reference %s points to an item that failed", v)
+    }
+    for _, field := range strings.Split(match[2], ".")[1:] {
+      switch container := value.(type) {
+      case map[string]interface{}:
+        value = container[field]
+      case []interface{}:
+        i, err := strconv.Atoi(field)
+        if err != nil || i >= len(container) {
+          return nil, fmt.Errorf("Reference %s has no element %s", v, field)
+        }
+        value = container[i]
+      default:
+        value = nil
+      }
+      if value == nil {
+        return nil, fmt.Errorf("Reference %s has no field %s", v, field)
+      }
+    }
+    return value, nil
+  }
+  return params, nil
+}
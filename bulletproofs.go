@@ -0,0 +1,397 @@
+package main
+
+import (
+  "crypto/rand"
+  "errors"
+  "fmt"
+  "math/big"
+  "sync"
+  "github.com/rynobey/bn256"
+)
+
+// aggregated Bulletproofs range proofs (Bunz et al., section 4.3) that m
+// Pedersen commitments V_j = v_j*G + gamma_j*H hold values in [0, 2^n), with
+// the inner product argument of section 3 and a Fiat-Shamir Transcript. The
+// generator vectors G_i, H_i and the inner product generator U are hashed to
+// the curve from "bulletproofs/G/i", "bulletproofs/H/i" and "bulletproofs/U"
+
+const MaxRangeProofBits = 64
+const MaxRangeProofValues = 16
+
+type BulletproofGenerators struct {
+  sync.Mutex
+  G   []*bn256.G1
+  H   []*bn256.G1
+  U   *bn256.G1
+}
+
+// hashing to the curve is slow, so the generators are derived once and kept
+var bulletproofGenerators = &BulletproofGenerators{}
+
+func RangeProofGenerators(size int, err error) ([]*bn256.G1, []*bn256.G1, *bn256.G1, error) {
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  gens := bulletproofGenerators
+  gens.Lock()
+  defer gens.Unlock()
+  if gens.U == nil {
+    gens.U, err = NUMSGenerator("bulletproofs/U", err)
+  }
+  for i := len(gens.G); i < size && err == nil; i++ {
+    var G, H *bn256.G1
+    G, err = NUMSGenerator(fmt.Sprintf("bulletproofs/G/%d", i), err)
+    H, err = NUMSGenerator(fmt.Sprintf("bulletproofs/H/%d", i), err)
+    if err == nil {
+      gens.G = append(gens.G, G)
+      gens.H = append(gens.H, H)
+    }
+  }
+  if err != nil {
+    gens.U = nil
+    return nil, nil, nil, err
+  }
+  return gens.G[:size], gens.H[:size], gens.U, nil
+}
+
+type RangeProofData struct {
+  A     *bn256.G1
+  S     *bn256.G1
+  T1    *bn256.G1
+  T2    *bn256.G1
+  TauX  *big.Int
+  Mu    *big.Int
+  T     *big.Int
+  L     []*bn256.G1
+  R     []*bn256.G1
+  IPA   *big.Int
+  IPB   *big.Int
+}
+
+func CheckRangeProofSize(n int, m int) (error) {
+  if n <= 0 || n > MaxRangeProofBits || n & (n - 1) != 0 {
+    return fmt.Errorf("Number of bits must be a power of two up to %d", MaxRangeProofBits)
+  }
+  if m <= 0 || m > MaxRangeProofValues || m & (m - 1) != 0 {
+    return fmt.Errorf("Number of values must be a power of two up to %d", MaxRangeProofValues)
+  }
+  return nil
+}
+
+func RangeProofTranscript(G *bn256.G1, H *bn256.G1, Vs []*bn256.G1, n int) (*Transcript) {
+  t := NewTranscript("bulletproofs/range")
+  t.AppendInt("n", n)
+  t.AppendInt("m", len(Vs))
+  t.AppendPoint("G", G)
+  t.AppendPoint("H", H)
+  for _, V := range Vs {
+    t.AppendPoint("V", V)
+  }
+  return t
+}
+
+// 1, x, x^2, ..., x^(size-1) mod r
+func ScalarPowers(x *big.Int, size int) ([]*big.Int) {
+  powers := make([]*big.Int, size)
+  acc := big.NewInt(1)
+  for i := range powers {
+    powers[i] = new(big.Int).Set(acc)
+    acc.Mul(acc, x).Mod(acc, bn256.Order)
+  }
+  return powers
+}
+
+func InnerProduct(a []*big.Int, b []*big.Int) (*big.Int) {
+  ans := new(big.Int)
+  for i := range a {
+    ans.Add(ans, new(big.Int).Mul(a[i], b[i]))
+  }
+  return ans.Mod(ans, bn256.Order)
+}
+
+func RandomScalars(size int) ([]*big.Int, error) {
+  scalars := make([]*big.Int, size)
+  for i := range scalars {
+    s, err := rand.Int(rand.Reader, bn256.Order)
+    if err != nil {
+      return nil, err
+    }
+    scalars[i] = s
+  }
+  return scalars, nil
+}
+
+// d_i = z^(2+j) * 2^k for i = j*n + k, the constant term that puts the bits of
+// value j into the inner product
+func RangeProofBitWeights(z *big.Int, n int, m int) ([]*big.Int) {
+  twos := ScalarPowers(big.NewInt(2), n)
+  zs := ScalarPowers(z, m+2)
+  d := make([]*big.Int, n*m)
+  for j := 0; j < m; j++ {
+    for k := 0; k < n; k++ {
+      d[j*n+k] = new(big.Int).Mul(zs[j+2], twos[k])
+      d[j*n+k].Mod(d[j*n+k], bn256.Order)
+    }
+  }
+  return d
+}
+
+func ProveRange(G *bn256.G1, H *bn256.G1, vs []*big.Int, gammas []*big.Int, n int, err error) ([]*bn256.G1, *RangeProofData, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  m := len(vs)
+  if len(gammas) != m {
+    return nil, nil, errors.New("Number of values and blinding factors must be equal")
+  }
+  err = CheckRangeProofSize(n, m)
+  if err != nil {
+    return nil, nil, err
+  }
+  bound := new(big.Int).Lsh(big.NewInt(1), uint(n))
+  for j, v := range vs {
+    if v.Sign() < 0 || v.Cmp(bound) >= 0 {
+      return nil, nil, fmt.Errorf("Value at index %d is not in [0, 2^%d)", j, n)
+    }
+  }
+  Gs, Hs, U, err := RangeProofGenerators(n*m, err)
+  if err != nil {
+    return nil, nil, err
+  }
+  Vs := make([]*bn256.G1, m)
+  for j := range vs {
+    Vs[j] = PedersenCommit(G, H, vs[j], gammas[j])
+  }
+  t := RangeProofTranscript(G, H, Vs, n)
+
+  // a_L holds the bits of all values, a_R = a_L - 1
+  aL := make([]*big.Int, n*m)
+  aR := make([]*big.Int, n*m)
+  for j := range vs {
+    for k := 0; k < n; k++ {
+      aL[j*n+k] = big.NewInt(int64(vs[j].Bit(k)))
+      aR[j*n+k] = new(big.Int).Mod(new(big.Int).Sub(aL[j*n+k], big.NewInt(1)), bn256.Order)
+    }
+  }
+  blinds, err := RandomScalars(4)
+  sL, err := RandomScalars(n*m)
+  sR, err := RandomScalars(n*m)
+  if err != nil {
+    return nil, nil, err
+  }
+  alpha, rho, tau1, tau2 := blinds[0], blinds[1], blinds[2], blinds[3]
+  scalars := append(append([]*big.Int{alpha}, aL...), aR...)
+  points := append(append([]*bn256.G1{H}, Gs...), Hs...)
+  A, err := MultiScalarMult(scalars, points, err)
+  scalars = append(append([]*big.Int{rho}, sL...), sR...)
+  S, err := MultiScalarMult(scalars, points, err)
+  if err != nil {
+    return nil, nil, err
+  }
+  t.AppendPoint("A", A)
+  t.AppendPoint("S", S)
+  y := t.Challenge("y")
+  z := t.Challenge("z")
+
+  // l(X) = l0 + l1*X and r(X) = r0 + r1*X
+  ys := ScalarPowers(y, n*m)
+  d := RangeProofBitWeights(z, n, m)
+  l0 := make([]*big.Int, n*m)
+  r0 := make([]*big.Int, n*m)
+  r1 := make([]*big.Int, n*m)
+  for i := range l0 {
+    l0[i] = new(big.Int).Mod(new(big.Int).Sub(aL[i], z), bn256.Order)
+    r0[i] = new(big.Int).Add(aR[i], z)
+    r0[i].Mul(r0[i], ys[i]).Add(r0[i], d[i]).Mod(r0[i], bn256.Order)
+    r1[i] = new(big.Int).Mul(ys[i], sR[i])
+    r1[i].Mod(r1[i], bn256.Order)
+  }
+  t1 := new(big.Int).Add(InnerProduct(l0, r1), InnerProduct(sL, r0))
+  t1.Mod(t1, bn256.Order)
+  t2 := InnerProduct(sL, r1)
+  T1 := PedersenCommit(G, H, t1, tau1)
+  T2 := PedersenCommit(G, H, t2, tau2)
+  t.AppendPoint("T1", T1)
+  t.AppendPoint("T2", T2)
+  x := t.Challenge("x")
+
+  l := make([]*big.Int, n*m)
+  r := make([]*big.Int, n*m)
+  for i := range l {
+    l[i] = new(big.Int).Mul(sL[i], x)
+    l[i].Add(l[i], l0[i]).Mod(l[i], bn256.Order)
+    r[i] = new(big.Int).Mul(r1[i], x)
+    r[i].Add(r[i], r0[i]).Mod(r[i], bn256.Order)
+  }
+  tHat := InnerProduct(l, r)
+  zs := ScalarPowers(z, m+2)
+  tauX := new(big.Int).Mul(tau2, new(big.Int).Mul(x, x))
+  tauX.Add(tauX, new(big.Int).Mul(tau1, x))
+  for j := range gammas {
+    tauX.Add(tauX, new(big.Int).Mul(zs[j+2], gammas[j]))
+  }
+  tauX.Mod(tauX, bn256.Order)
+  mu := new(big.Int).Mul(rho, x)
+  mu.Add(mu, alpha).Mod(mu, bn256.Order)
+  t.AppendScalar("taux", tauX)
+  t.AppendScalar("mu", mu)
+  t.AppendScalar("t", tHat)
+  w := t.Challenge("w")
+
+  // the inner product argument for <l, r> = t with H'_i = y^-i * H_i and
+  // Q = w*U
+  Q := new(bn256.G1).ScalarMult(U, w)
+  yInv := new(big.Int).ModInverse(y, bn256.Order)
+  yInvs := ScalarPowers(yInv, n*m)
+  Hp := make([]*bn256.G1, n*m)
+  for i := range Hp {
+    Hp[i] = new(bn256.G1).ScalarMult(Hs[i], yInvs[i])
+  }
+  Gp := append([]*bn256.G1{}, Gs...)
+  Ls := []*bn256.G1{}
+  Rs := []*bn256.G1{}
+  for size := n*m; size > 1; size /= 2 {
+    half := size/2
+    cL := InnerProduct(l[:half], r[half:])
+    cR := InnerProduct(l[half:], r[:half])
+    scalars := append(append(append([]*big.Int{}, l[:half]...), r[half:]...), cL)
+    points := append(append(append([]*bn256.G1{}, Gp[half:]...), Hp[:half]...), Q)
+    L, err := MultiScalarMult(scalars, points, err)
+    scalars = append(append(append([]*big.Int{}, l[half:]...), r[:half]...), cR)
+    points = append(append(append([]*bn256.G1{}, Gp[:half]...), Hp[half:]...), Q)
+    R, err := MultiScalarMult(scalars, points, err)
+    if err != nil {
+      return nil, nil, err
+    }
+    Ls = append(Ls, L)
+    Rs = append(Rs, R)
+    t.AppendPoint("L", L)
+    t.AppendPoint("R", R)
+    u := t.Challenge("u")
+    uInv := new(big.Int).ModInverse(u, bn256.Order)
+    for i := 0; i < half; i++ {
+      l[i] = new(big.Int).Add(new(big.Int).Mul(l[i], u), new(big.Int).Mul(l[half+i], uInv))
+      l[i].Mod(l[i], bn256.Order)
+      r[i] = new(big.Int).Add(new(big.Int).Mul(r[i], uInv), new(big.Int).Mul(r[half+i], u))
+      r[i].Mod(r[i], bn256.Order)
+      Gp[i] = new(bn256.G1).Add(new(bn256.G1).ScalarMult(Gp[i], uInv), new(bn256.G1).ScalarMult(Gp[half+i], u))
+      Hp[i] = new(bn256.G1).Add(new(bn256.G1).ScalarMult(Hp[i], u), new(bn256.G1).ScalarMult(Hp[half+i], uInv))
+    }
+    l, r, Gp, Hp = l[:half], r[:half], Gp[:half], Hp[:half]
+  }
+  proof := &RangeProofData{A: A, S: S, T1: T1, T2: T2, TauX: tauX, Mu: mu, T: tHat, L: Ls, R: Rs, IPA: l[0], IPB: r[0]}
+  return Vs, proof, nil
+}
+
+func VerifyRangeProof(G *bn256.G1, H *bn256.G1, Vs []*bn256.G1, n int, proof *RangeProofData, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  m := len(Vs)
+  err = CheckRangeProofSize(n, m)
+  if err != nil {
+    return false, err
+  }
+  rounds := 0
+  for 1 << uint(rounds) < n*m {
+    rounds++
+  }
+  if len(proof.L) != rounds || len(proof.R) != rounds {
+    return false, fmt.Errorf("Proof must have %d L and R points", rounds)
+  }
+  Gs, Hs, U, err := RangeProofGenerators(n*m, err)
+  if err != nil {
+    return false, err
+  }
+  t := RangeProofTranscript(G, H, Vs, n)
+  t.AppendPoint("A", proof.A)
+  t.AppendPoint("S", proof.S)
+  y := t.Challenge("y")
+  z := t.Challenge("z")
+  t.AppendPoint("T1", proof.T1)
+  t.AppendPoint("T2", proof.T2)
+  x := t.Challenge("x")
+  t.AppendScalar("taux", proof.TauX)
+  t.AppendScalar("mu", proof.Mu)
+  t.AppendScalar("t", proof.T)
+  w := t.Challenge("w")
+  us := make([]*big.Int, rounds)
+  for k := range us {
+    t.AppendPoint("L", proof.L[k])
+    t.AppendPoint("R", proof.R[k])
+    us[k] = t.Challenge("u")
+  }
+
+  // t*G + taux*H = sum_j z^(2+j)*V_j + delta(y, z)*G + x*T1 + x^2*T2 with
+  // delta(y, z) = (z - z^2) * <1, y^nm> - sum_j z^(3+j) * <1, 2^n>
+  ys := ScalarPowers(y, n*m)
+  zs := ScalarPowers(z, m+3)
+  sumY := InnerProduct(ys, ScalarPowers(big.NewInt(1), n*m))
+  sumTwos := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(n)), big.NewInt(1))
+  delta := new(big.Int).Mul(new(big.Int).Sub(z, zs[2]), sumY)
+  for j := 0; j < m; j++ {
+    delta.Sub(delta, new(big.Int).Mul(zs[j+3], sumTwos))
+  }
+  scalars := []*big.Int{new(big.Int).Sub(proof.T, delta), proof.TauX, new(big.Int).Neg(x), new(big.Int).Neg(new(big.Int).Mul(x, x))}
+  points := []*bn256.G1{G, H, proof.T1, proof.T2}
+  for j := range Vs {
+    scalars = append(scalars, new(big.Int).Neg(zs[j+2]))
+    points = append(points, Vs[j])
+  }
+  check, err := MultiScalarMult(scalars, points, err)
+  if err != nil {
+    return false, err
+  }
+  if !IsInfinity(check) {
+    return false, nil
+  }
+
+  // with s_i the product of u_k or u_k^-1 by the bits of i, the folded
+  // generators are <s, G> and <s^-1, H'>, so the argument holds iff
+  //   a*<s, G> + b*<s^-1, H'> + a*b*Q
+  //     = A + x*S - z*<1, G> + <z*y^nm + d, H'> - mu*H + t*Q
+  //       + sum_k (u_k^2*L_k + u_k^-2*R_k)
+  // which is checked as a single multi-scalar multiplication
+  uInvs := make([]*big.Int, rounds)
+  for k := range us {
+    uInvs[k] = new(big.Int).ModInverse(us[k], bn256.Order)
+  }
+  s := make([]*big.Int, n*m)
+  for i := range s {
+    s[i] = big.NewInt(1)
+    for k := 0; k < rounds; k++ {
+      if (i >> uint(rounds-1-k)) & 1 == 1 {
+        s[i].Mul(s[i], us[k])
+      } else {
+        s[i].Mul(s[i], uInvs[k])
+      }
+      s[i].Mod(s[i], bn256.Order)
+    }
+  }
+  d := RangeProofBitWeights(z, n, m)
+  yInvs := ScalarPowers(new(big.Int).ModInverse(y, bn256.Order), n*m)
+  scalars = []*big.Int{}
+  points = []*bn256.G1{}
+  for i := range s {
+    // s^-1_i is s_(nm-1-i)
+    gi := new(big.Int).Add(new(big.Int).Mul(proof.IPA, s[i]), z)
+    hi := new(big.Int).Mul(proof.IPB, s[n*m-1-i])
+    hi.Sub(hi, d[i]).Mul(hi, yInvs[i]).Sub(hi, z)
+    scalars = append(scalars, gi, hi)
+    points = append(points, Gs[i], Hs[i])
+  }
+  ab := new(big.Int).Mul(proof.IPA, proof.IPB)
+  scalars = append(scalars, new(big.Int).Mul(w, ab.Sub(ab, proof.T)), proof.Mu, big.NewInt(-1), new(big.Int).Neg(x))
+  points = append(points, U, H, proof.A, proof.S)
+  for k := range us {
+    u2 := new(big.Int).Mul(us[k], us[k])
+    u2Inv := new(big.Int).Mul(uInvs[k], uInvs[k])
+    scalars = append(scalars, u2.Neg(u2), u2Inv.Neg(u2Inv))
+    points = append(points, proof.L[k], proof.R[k])
+  }
+  check, err = MultiScalarMult(scalars, points, err)
+  if err != nil {
+    return false, err
+  }
+  return IsInfinity(check), nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+  "crypto/sha256"
+  "encoding/binary"
+  "encoding/hex"
+  "fmt"
+  "hash"
+  "math/big"
+  "github.com/ethereum/go-ethereum/crypto/sha3"
+)
+
+// the Schnorr challenge schemes; a signature without a scheme uses "legacy",
+// the original keccak256 over the hex strings of M and the coordinates
+//   legacy            e = keccak256(M || P.X || P.Y || kG.X || kG.Y), as text
+//   binary-keccak256  e = keccak256(encoding) mod N, see SchnorrChallengeBinary
+//   binary-sha256     e = sha256(encoding) mod N, see SchnorrChallengeBinary
+//   solidity          e = uint256(keccak256(abi.encodePacked(
+//                           P.X, P.Y, kG.X, kG.Y, bytes(M)))) mod N
+var SchnorrChallengeSchemes = []string{"legacy", "binary-keccak256", "binary-sha256", "solidity"}
+
+const SchnorrChallengeDST = "ECC-API-SCHNORR-V01-"
+
+func CheckSchnorrChallengeScheme(scheme string) (error) {
+  if scheme == "" {
+    return nil
+  }
+  for _, name := range SchnorrChallengeSchemes {
+    if name == scheme {
+      return nil
+    }
+  }
+  return fmt.Errorf("Unknown challenge scheme: %s", scheme)
+}
+
+func SchnorrChallengeWithScheme(curve Curve, scheme string, M string, P Point, kG Point) (*big.Int, error) {
+  switch scheme {
+  case "", "legacy":
+    return SchnorrChallenge(M, P, kG), nil
+  case "binary-keccak256":
+    return SchnorrChallengeBinary(curve, "keccak256", M, P, kG), nil
+  case "binary-sha256":
+    return SchnorrChallengeBinary(curve, "sha256", M, P, kG), nil
+  case "solidity":
+    h := sha3.NewKeccak256()
+    h.Write(PointBytes(P))
+    h.Write(PointBytes(kG))
+    h.Write([]byte(M))
+    e := new(big.Int).SetBytes(h.Sum(nil))
+    return e.Mod(e, curve.Params().N), nil
+  default:
+    return nil, fmt.Errorf("Unknown challenge scheme: %s", scheme)
+  }
+}
+
+// the domain separated binary encoding hashed by the binary-* schemes is
+//   len(dst) || dst || len(M) || M || P.X || P.Y || kG.X || kG.Y
+// where dst is SchnorrChallengeDST followed by the curve and hash names, the
+// first length is a single byte, the second is 8 bytes big endian, and the
+// coordinates are 32 bytes big endian (all zero for the point at infinity)
+func SchnorrChallengeBinary(curve Curve, hashName string, M string, P Point, kG Point) (*big.Int) {
+  var h hash.Hash
+  if hashName == "sha256" {
+    h = sha256.New()
+  } else {
+    h = sha3.NewKeccak256()
+  }
+  dst := SchnorrChallengeDST + curve.Params().Name + "-" + hashName
+  length := make([]byte, 8)
+  binary.BigEndian.PutUint64(length, uint64(len(M)))
+  h.Write([]byte{byte(len(dst))})
+  h.Write([]byte(dst))
+  h.Write(length)
+  h.Write([]byte(M))
+  h.Write(PointBytes(P))
+  h.Write(PointBytes(kG))
+  e := new(big.Int).SetBytes(h.Sum(nil))
+  return e.Mod(e, curve.Params().N)
+}
+
+// the 64 byte X || Y encoding of a point, as abi.encodePacked(uint256, uint256)
+func PointBytes(pt Point) ([]byte) {
+  curvePoint := pt.CurvePoint()
+  x, _ := hex.DecodeString(curvePoint.X[2:])
+  y, _ := hex.DecodeString(curvePoint.Y[2:])
+  return append(x, y...)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+  "bytes"
+  "errors"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// Pedersen commitments C = v*G + b*H are additively homomorphic: C1 + C2
+// commits to v1 + v2 with blinding b1 + b2, and k*C to k*v with k*b
+
+func CommitmentSum(commitments []*bn256.G1) (*bn256.G1) {
+  sum := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+  for _, C := range commitments {
+    sum = new(bn256.G1).Add(sum, C)
+  }
+  return sum
+}
+
+// the inputs and outputs balance when sum(inputs) - sum(outputs) = excess*H,
+// i.e. they commit to the same total value and the blinding factors differ by
+// the revealed excess
+func CommitmentsBalance(inputs []*bn256.G1, outputs []*bn256.G1, excess *big.Int, H *bn256.G1, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  if len(inputs) == 0 || len(outputs) == 0 {
+    return false, errors.New("At least one input and one output commitment are required")
+  }
+  diff := new(bn256.G1).Add(CommitmentSum(inputs), new(bn256.G1).Neg(CommitmentSum(outputs)))
+  expected := new(bn256.G1).ScalarMult(H, excess)
+  return bytes.Equal(diff.Marshal(), expected.Marshal()), nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+func CommitmentAdd(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var commitmentListInputs CommitmentListInputs
+  err := ReadContentsIntoStruct(r, &commitmentListInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if len(commitmentListInputs.Commitments) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "At least one commitment is required"}})
+    return
+  }
+  commitments, err := NewECPointsFromCurvePoints(commitmentListInputs.Commitments, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: NewCurvePoint(CommitmentSum(commitments))})
+}
+
+func CommitmentSub(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryEcOpParams BinaryEcOpParams
+  err := ReadContentsIntoStruct(r, &binaryEcOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := NewECPointFromCurvePoint(binaryEcOpParams.A, err)
+  B, err := NewECPointFromCurvePoint(binaryEcOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: NewCurvePoint(new(bn256.G1).Add(A, new(bn256.G1).Neg(B)))})
+}
+
+func CommitmentScale(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var commitmentScaleInputs CommitmentScaleInputs
+  err := ReadContentsIntoStruct(r, &commitmentScaleInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  C, err := NewECPointFromCurvePoint(commitmentScaleInputs.C, err)
+  k, err := NewBigInt(commitmentScaleInputs.K, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  k.Mod(k, bn256.Order)
+  encoder.Encode(Response{P: NewCurvePoint(new(bn256.G1).ScalarMult(C, k))})
+}
+
+func CommitmentBalance(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var commitmentBalanceInputs CommitmentBalanceInputs
+  err := ReadContentsIntoStruct(r, &commitmentBalanceInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  inputs, err := NewECPointsFromCurvePoints(commitmentBalanceInputs.Inputs, err)
+  outputs, err := NewECPointsFromCurvePoints(commitmentBalanceInputs.Outputs, err)
+  excess, err := NewBigInt(commitmentBalanceInputs.Excess, err)
+  _, H, err := PedersenGenerators(nil, commitmentBalanceInputs.H, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  balanced, err := CommitmentsBalance(inputs, outputs, new(big.Int).Mod(excess, bn256.Order), H, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", balanced)})
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+  "bytes"
+  "errors"
+  "fmt"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// zero-knowledge proofs about Pedersen commitments C_i = v_i*G + b_i*H, none
+// of which reveal the values or blinding factors
+//   opening   knowledge of (v, b) for C: R = k1*G + k2*H,
+//             c = H(context, G, H, C, R), sv = k1 + c*v, sb = k2 + c*b
+//   equality  C1 and C2 commit to the same value: D = C1 - C2 = (b1 - b2)*H
+//   sum       C3 commits to the sum of the values of C1 and C2:
+//             D = C1 + C2 - C3 = (b1 + b2 - b3)*H
+// For equality and sum, the proof is a proof of knowledge of the discrete log
+// of D to the base H. It holds only if the G parts cancel, as long as nobody
+// knows the discrete log of H to the base G
+
+var CommitmentProofSizes = map[string]int{"opening": 1, "equality": 2, "sum": 3}
+
+func CommitmentProofTranscript(kind string, G *bn256.G1, H *bn256.G1, Cs []*bn256.G1, context string) (*Transcript) {
+  t := NewTranscript("proof/commitment/" + kind)
+  t.Append("context", []byte(context))
+  t.AppendPoint("G", G)
+  t.AppendPoint("H", H)
+  for _, C := range Cs {
+    t.AppendPoint("C", C)
+  }
+  return t
+}
+
+func ProveCommitmentOpening(G *bn256.G1, H *bn256.G1, v *big.Int, b *big.Int, context string, err error) (*bn256.G1, *bn256.G1, *big.Int, *big.Int, error) {
+  if err != nil {
+    return nil, nil, nil, nil, err
+  }
+  ks, err := RandomScalars(2)
+  if err != nil {
+    return nil, nil, nil, nil, err
+  }
+  C := PedersenCommit(G, H, new(big.Int).Mod(v, bn256.Order), new(big.Int).Mod(b, bn256.Order))
+  R := PedersenCommit(G, H, ks[0], ks[1])
+  t := CommitmentProofTranscript("opening", G, H, []*bn256.G1{C}, context)
+  t.AppendPoint("R", R)
+  c := t.Challenge("c")
+  sv := new(big.Int).Mul(c, v)
+  sv.Add(sv, ks[0]).Mod(sv, bn256.Order)
+  sb := new(big.Int).Mul(c, b)
+  sb.Add(sb, ks[1]).Mod(sb, bn256.Order)
+  return C, R, sv, sb, nil
+}
+
+// sv*G + sb*H = R + c*C
+func VerifyCommitmentOpening(G *bn256.G1, H *bn256.G1, C *bn256.G1, R *bn256.G1, sv *big.Int, sb *big.Int, context string, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  t := CommitmentProofTranscript("opening", G, H, []*bn256.G1{C}, context)
+  t.AppendPoint("R", R)
+  c := t.Challenge("c")
+  expected := new(bn256.G1).Add(R, new(bn256.G1).ScalarMult(C, c))
+  return bytes.Equal(PedersenCommit(G, H, sv, sb).Marshal(), expected.Marshal()), nil
+}
+
+// D for an equality or sum proof
+func CommitmentRelationDifference(kind string, Cs []*bn256.G1) (*bn256.G1) {
+  if kind == "sum" {
+    D := new(bn256.G1).Add(Cs[0], Cs[1])
+    return new(bn256.G1).Add(D, new(bn256.G1).Neg(Cs[2]))
+  }
+  return new(bn256.G1).Add(Cs[0], new(bn256.G1).Neg(Cs[1]))
+}
+
+func CheckCommitmentRelation(kind string, n int) (error) {
+  if kind != "equality" && kind != "sum" {
+    return fmt.Errorf("Unknown commitment relation: %s", kind)
+  }
+  if n != CommitmentProofSizes[kind] {
+    return fmt.Errorf("Number of commitments must be %d", CommitmentProofSizes[kind])
+  }
+  return nil
+}
+
+func ProveCommitmentRelation(kind string, G *bn256.G1, H *bn256.G1, vs []*big.Int, bs []*big.Int, context string, err error) ([]*bn256.G1, *bn256.G1, *big.Int, error) {
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  err = CheckCommitmentRelation(kind, len(vs))
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  if len(bs) != len(vs) {
+    return nil, nil, nil, errors.New("Number of values and blinding factors must be equal")
+  }
+  Cs := make([]*bn256.G1, len(vs))
+  for i := range vs {
+    Cs[i] = PedersenCommit(G, H, new(big.Int).Mod(vs[i], bn256.Order), new(big.Int).Mod(bs[i], bn256.Order))
+  }
+  vDiff := new(big.Int).Sub(vs[0], vs[1])
+  delta := new(big.Int).Sub(bs[0], bs[1])
+  if kind == "sum" {
+    vDiff = new(big.Int).Add(vs[0], vs[1])
+    vDiff.Sub(vDiff, vs[2])
+    delta = new(big.Int).Add(bs[0], bs[1])
+    delta.Sub(delta, bs[2])
+  }
+  if !IsZero(vDiff.Mod(vDiff, bn256.Order)) {
+    if kind == "sum" {
+      return nil, nil, nil, errors.New("The third value is not the sum of the first two")
+    }
+    return nil, nil, nil, errors.New("The values are not equal")
+  }
+  t := CommitmentProofTranscript(kind, G, H, Cs, context)
+  R, sb, err := ProveDLogInTranscript(t, H, delta.Mod(delta, bn256.Order))
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  return Cs, R, sb, nil
+}
+
+func VerifyCommitmentRelation(kind string, G *bn256.G1, H *bn256.G1, Cs []*bn256.G1, R *bn256.G1, sb *big.Int, context string, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  err = CheckCommitmentRelation(kind, len(Cs))
+  if err != nil {
+    return false, err
+  }
+  t := CommitmentProofTranscript(kind, G, H, Cs, context)
+  return VerifyDLogInTranscript(t, H, CommitmentRelationDifference(kind, Cs), R, sb), nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+  "bytes"
+  "errors"
+  "fmt"
+  "math/big"
+  "net/http"
+  "strings"
+  "crypto/elliptic"
+  "github.com/ethereum/go-ethereum/crypto/secp256k1"
+  "github.com/rynobey/bn256"
+)
+
+// short Weierstrass curve y^2 = x^3 + a*x + b over F_p with a generator
+// (Gx, Gy) of prime order N
+type CurveParams struct {
+  Name  string
+  P     *big.Int
+  A     *big.Int
+  B     *big.Int
+  N     *big.Int
+  Gx    *big.Int
+  Gy    *big.Int
+}
+
+// x^3 + a*x + b mod p
+func (params *CurveParams) RHS(x *big.Int) (*big.Int) {
+  rhs := new(big.Int).Exp(x, big.NewInt(3), params.P)
+  rhs.Add(rhs, new(big.Int).Mul(params.A, x))
+  rhs.Add(rhs, params.B)
+  return rhs.Mod(rhs, params.P)
+}
+
+type Curve interface {
+  Params() (*CurveParams)
+  NewPoint(pt *CurvePoint, err error) (Point, error)
+  BaseMul(k *big.Int) (Point)
+  Identity() (Point)
+}
+
+type Point interface {
+  Add(Q Point) (Point)
+  Neg() (Point)
+  Mul(k *big.Int) (Point)
+  Equal(Q Point) (bool)
+  IsInfinity() (bool)
+  CurvePoint() (*CurvePoint)
+}
+
+var BN256Params = &CurveParams{
+  Name: "bn256",
+  P: bn256.P,
+  A: big.NewInt(0),
+  B: big.NewInt(3),
+  N: bn256.Order,
+  Gx: big.NewInt(1),
+  Gy: big.NewInt(2),
+}
+
+var Secp256k1Params = &CurveParams{
+  Name: "secp256k1",
+  P: HexToBigInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+  A: big.NewInt(0),
+  B: big.NewInt(7),
+  N: HexToBigInt("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+  Gx: HexToBigInt("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+  Gy: HexToBigInt("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+}
+
+var P256Params = &CurveParams{
+  Name: "p256",
+  P: elliptic.P256().Params().P,
+  A: big.NewInt(-3),
+  B: elliptic.P256().Params().B,
+  N: elliptic.P256().Params().N,
+  Gx: elliptic.P256().Params().Gx,
+  Gy: elliptic.P256().Params().Gy,
+}
+
+var BN256 = &BN256Curve{}
+var Secp256k1 = &WeierstrassCurve{params: Secp256k1Params, ct: secp256k1.S256()}
+var P256 = &WeierstrassCurve{params: P256Params, ct: elliptic.P256()}
+
+var Curves = map[string]Curve{
+  "bn256": BN256,
+  "bn254": BN256,
+  "alt_bn128": BN256,
+  "secp256k1": Secp256k1,
+  "p256": P256,
+  "p-256": P256,
+  "secp256r1": P256,
+  "prime256v1": P256,
+}
+
+// the curve is chosen with ?curve=<name> and defaults to bn256
+func CurveFromRequest(r *http.Request) (Curve, error) {
+  name := strings.ToLower(r.URL.Query().Get("curve"))
+  if name == "" {
+    return BN256, nil
+  }
+  curve, ok := Curves[name]
+  if !ok {
+    return nil, fmt.Errorf("Unknown curve: %s", name)
+  }
+  return curve, nil
+}
+
+// the generic big.Int arithmetic takes time that depends on the scalar, so
+// secret scalars (signing keys and nonces) are refused on a curve that has no
+// constant time implementation; such a curve can still verify signatures
+func RequireConstantTime(curve Curve) (error) {
+  if wc, ok := curve.(*WeierstrassCurve); ok && wc.ct == nil {
+    return fmt.Errorf("Signing is not available on %s, its scalar multiplication is not constant time", curve.Params().Name)
+  }
+  return nil
+}
+
+func RequireBN256(r *http.Request) (error) {
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    return err
+  }
+  if curve != BN256 {
+    return fmt.Errorf("This route is only available on bn256, not %s", curve.Params().Name)
+  }
+  return nil
+}
+
+func HexToBigInt(hexStr string) (*big.Int) {
+  num, _ := new(big.Int).SetString(hexStr, 16)
+  return num
+}
+
+type BN256Curve struct{}
+
+type BN256Point struct {
+  G1  *bn256.G1
+}
+
+func (curve *BN256Curve) Params() (*CurveParams) {
+  return BN256Params
+}
+
+func (curve *BN256Curve) NewPoint(pt *CurvePoint, err error) (Point, error) {
+  P, err := NewECPointFromCurvePoint(pt, err)
+  if err != nil {
+    return nil, err
+  }
+  return &BN256Point{G1: P}, nil
+}
+
+func (curve *BN256Curve) BaseMul(k *big.Int) (Point) {
+  return &BN256Point{G1: new(bn256.G1).ScalarBaseMult(k)}
+}
+
+func (curve *BN256Curve) Identity() (Point) {
+  return curve.BaseMul(new(big.Int))
+}
+
+func (P *BN256Point) Add(Q Point) (Point) {
+  return &BN256Point{G1: new(bn256.G1).Add(P.G1, Q.(*BN256Point).G1)}
+}
+
+func (P *BN256Point) Neg() (Point) {
+  return &BN256Point{G1: new(bn256.G1).Neg(P.G1)}
+}
+
+func (P *BN256Point) Mul(k *big.Int) (Point) {
+  return &BN256Point{G1: new(bn256.G1).ScalarMult(P.G1, k)}
+}
+
+func (P *BN256Point) Equal(Q Point) (bool) {
+  return bytes.Equal(P.G1.Marshal(), Q.(*BN256Point).G1.Marshal())
+}
+
+func (P *BN256Point) IsInfinity() (bool) {
+  return IsInfinity(P.G1)
+}
+
+func (P *BN256Point) CurvePoint() (*CurvePoint) {
+  return NewCurvePoint(P.G1)
+}
+
+// generic affine arithmetic with big.Int, used for the curves that have no
+// dedicated implementation; scalar multiplication goes through ct, a constant
+// time implementation (the standard library for p256, libsecp256k1 through
+// go-ethereum for secp256k1), when there is one
+type WeierstrassCurve struct {
+  params  *CurveParams
+  ct      elliptic.Curve
+}
+
+type WeierstrassPoint struct {
+  curve   *WeierstrassCurve
+  X       *big.Int
+  Y       *big.Int
+  Inf     bool
+}
+
+func (curve *WeierstrassCurve) Params() (*CurveParams) {
+  return curve.params
+}
+
+func (curve *WeierstrassCurve) NewPoint(pt *CurvePoint, err error) (Point, error) {
+  if err != nil {
+    return nil, err
+  }
+  if pt == nil {
+    return nil, errors.New("Missing curve point")
+  }
+  verdict, err := ValidateCurvePointOn(curve.params, pt, err)
+  if err != nil {
+    return nil, err
+  }
+  if verdict.Reason == "infinity" {
+    return curve.Identity(), nil
+  }
+  if !verdict.Valid {
+    return nil, errors.New(verdict.Msg)
+  }
+  if pt.C != "" {
+    pt, err = DecompressCurvePointOn(curve.params, pt.C, err)
+  }
+  x, err := NewBigInt(pt.X, err)
+  y, err := NewBigInt(pt.Y, err)
+  if err != nil {
+    return nil, err
+  }
+  return &WeierstrassPoint{curve: curve, X: x, Y: y}, nil
+}
+
+func (curve *WeierstrassCurve) BaseMul(k *big.Int) (Point) {
+  if curve.ct != nil {
+    return curve.affine(curve.ct.ScalarBaseMult(curve.scalarBytes(k)))
+  }
+  G := &WeierstrassPoint{curve: curve, X: curve.params.Gx, Y: curve.params.Gy}
+  return G.Mul(k)
+}
+
+// k mod N as a fixed length big endian scalar
+func (curve *WeierstrassCurve) scalarBytes(k *big.Int) ([]byte) {
+  N := curve.params.N
+  return RFC6979IntToOctets(new(big.Int).Mod(k, N), (N.BitLen() + 7)/8)
+}
+
+// crypto/elliptic returns (0, 0) for the point at infinity and go-ethereum
+// returns (nil, nil) for a zero scalar
+func (curve *WeierstrassCurve) affine(x *big.Int, y *big.Int) (Point) {
+  if x == nil || y == nil || (IsZero(x) && IsZero(y)) {
+    return curve.Identity()
+  }
+  return &WeierstrassPoint{curve: curve, X: x, Y: y}
+}
+
+func (curve *WeierstrassCurve) Identity() (Point) {
+  return &WeierstrassPoint{curve: curve, Inf: true}
+}
+
+func (P *WeierstrassPoint) Add(Q Point) (Point) {
+  R := Q.(*WeierstrassPoint)
+  p := P.curve.params.P
+  if P.Inf {
+    return R
+  }
+  if R.Inf {
+    return P
+  }
+  var lambda *big.Int
+  if P.X.Cmp(R.X) == 0 {
+    if new(big.Int).Add(P.Y, R.Y).Mod(new(big.Int).Add(P.Y, R.Y), p).Sign() == 0 {
+      return P.curve.Identity()
+    }
+    // doubling: lambda = (3x^2 + a) / 2y
+    num := new(big.Int).Mul(big.NewInt(3), new(big.Int).Mul(P.X, P.X))
+    num.Add(num, P.curve.params.A)
+    den := new(big.Int).ModInverse(new(big.Int).Lsh(P.Y, 1), p)
+    lambda = num.Mul(num, den)
+  } else {
+    // lambda = (y2 - y1) / (x2 - x1)
+    num := new(big.Int).Sub(R.Y, P.Y)
+    den := new(big.Int).Sub(R.X, P.X)
+    den.ModInverse(den.Mod(den, p), p)
+    lambda = num.Mul(num, den)
+  }
+  lambda.Mod(lambda, p)
+  x := new(big.Int).Mul(lambda, lambda)
+  x.Sub(x, P.X).Sub(x, R.X).Mod(x, p)
+  y := new(big.Int).Sub(P.X, x)
+  y.Mul(y, lambda).Sub(y, P.Y).Mod(y, p)
+  return &WeierstrassPoint{curve: P.curve, X: x, Y: y}
+}
+
+func (P *WeierstrassPoint) Neg() (Point) {
+  if P.Inf {
+    return P
+  }
+  y := new(big.Int).Sub(P.curve.params.P, P.Y)
+  return &WeierstrassPoint{curve: P.curve, X: P.X, Y: y.Mod(y, P.curve.params.P)}
+}
+
+func (P *WeierstrassPoint) Mul(k *big.Int) (Point) {
+  if P.Inf {
+    return P
+  }
+  if P.curve.ct != nil {
+    return P.curve.affine(P.curve.ct.ScalarMult(P.X, P.Y, P.curve.scalarBytes(k)))
+  }
+  k = new(big.Int).Mod(k, P.curve.params.N)
+  var R Point = P.curve.Identity()
+  for i := k.BitLen()-1; i >= 0; i-- {
+    R = R.Add(R)
+    if k.Bit(i) == 1 {
+      R = R.Add(P)
+    }
+  }
+  return R
+}
+
+func (P *WeierstrassPoint) Equal(Q Point) (bool) {
+  R := Q.(*WeierstrassPoint)
+  if P.Inf || R.Inf {
+    return P.Inf == R.Inf
+  }
+  return P.X.Cmp(R.X) == 0 && P.Y.Cmp(R.Y) == 0
+}
+
+func (P *WeierstrassPoint) IsInfinity() (bool) {
+  return P.Inf
+}
+
+func (P *WeierstrassPoint) CurvePoint() (*CurvePoint) {
+  if P.Inf {
+    return &CurvePoint{X: fmt.Sprintf("0x%064x", 0), Y: fmt.Sprintf("0x%064x", 0)}
+  }
+  return &CurvePoint{X: fmt.Sprintf("0x%064x", P.X), Y: fmt.Sprintf("0x%064x", P.Y)}
+}
@@ -1,77 +1,98 @@
 package main
 
 import (
+  "fmt"
   "net/http"
   "encoding/json"
+  "math/big"
   "github.com/rynobey/bn256"
 )
 
 func ECOrder(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
-  encoder.Encode(Response{Num: NewNumber(bn256.Order)})
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Num: NewNumber(curve.Params().N)})
 }
 
 func ECAdd(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   var binaryEcOpParams BinaryEcOpParams
-  err := ReadContentsIntoStruct(r, &binaryEcOpParams)
+  err = ReadContentsIntoStruct(r, &binaryEcOpParams)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  A, err := NewECPoint(binaryEcOpParams.A.X, binaryEcOpParams.A.Y, err)
-  B, err := NewECPoint(binaryEcOpParams.B.X, binaryEcOpParams.B.Y, err)
+  A, err := curve.NewPoint(binaryEcOpParams.A, err)
+  B, err := curve.NewPoint(binaryEcOpParams.B, err)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  ans := new(bn256.G1).Add(A, B)
-  curvePoint := NewCurvePoint(ans)
-  encoder.Encode(Response{P: curvePoint})
+  encoder.Encode(Response{P: A.Add(B).CurvePoint()})
 }
 
 func ECSub(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   var binaryEcOpParams BinaryEcOpParams
-  err := ReadContentsIntoStruct(r, &binaryEcOpParams)
+  err = ReadContentsIntoStruct(r, &binaryEcOpParams)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  A, err := NewECPoint(binaryEcOpParams.A.X, binaryEcOpParams.A.Y, err)
-  B, err := NewECPoint(binaryEcOpParams.B.X, binaryEcOpParams.B.Y, err)
+  A, err := curve.NewPoint(binaryEcOpParams.A, err)
+  B, err := curve.NewPoint(binaryEcOpParams.B, err)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  ans := new(bn256.G1).Add(A, B.Neg(B))
-  curvePoint := NewCurvePoint(ans)
-  encoder.Encode(Response{P: curvePoint})
+  encoder.Encode(Response{P: A.Add(B.Neg()).CurvePoint()})
 }
 
 func ECMul(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   var scalarEcOpParams ScalarEcOpParams
-  err := ReadContentsIntoStruct(r, &scalarEcOpParams)
+  err = ReadContentsIntoStruct(r, &scalarEcOpParams)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
   s, err := NewBigInt(scalarEcOpParams.S.V, err)
-  A, err := NewECPoint(scalarEcOpParams.A.X, scalarEcOpParams.A.Y, err)
+  A, err := curve.NewPoint(scalarEcOpParams.A, err)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  ans := new(bn256.G1).ScalarMult(A, s)
-  curvePoint := NewCurvePoint(ans)
-  encoder.Encode(Response{P: curvePoint})
+  encoder.Encode(Response{P: A.Mul(s).CurvePoint()})
 }
 
 func ECBaseMul(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   var number Number
-  err := ReadContentsIntoStruct(r, &number)
+  err = ReadContentsIntoStruct(r, &number)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
@@ -81,24 +102,245 @@ func ECBaseMul(w http.ResponseWriter, r *http.Request) {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  ans := new(bn256.G1).ScalarBaseMult(s)
-  curvePoint := NewCurvePoint(ans)
-  encoder.Encode(Response{P: curvePoint})
+  encoder.Encode(Response{P: curve.BaseMul(s).CurvePoint()})
+}
+
+func ECNeg(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  var curvePoint CurvePoint
+  err = ReadContentsIntoStruct(r, &curvePoint)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := curve.NewPoint(&curvePoint, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: A.Neg().CurvePoint()})
+}
+
+func ECEq(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  var binaryEcOpParams BinaryEcOpParams
+  err = ReadContentsIntoStruct(r, &binaryEcOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := curve.NewPoint(binaryEcOpParams.A, err)
+  B, err := curve.NewPoint(binaryEcOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", A.Equal(B))})
+}
+
+func ECIsInfinity(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  var curvePoint CurvePoint
+  err = ReadContentsIntoStruct(r, &curvePoint)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := curve.NewPoint(&curvePoint, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", A.IsInfinity())})
+}
+
+func ECValidate(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  var curvePoint CurvePoint
+  err = ReadContentsIntoStruct(r, &curvePoint)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  verdict, err := ValidateCurvePointOn(curve.Params(), &curvePoint, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Verdict: verdict})
+}
+
+func ECMSM(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  var msmParams MSMParams
+  err = ReadContentsIntoStruct(r, &msmParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if len(msmParams.S) != len(msmParams.A) {
+    encoder.Encode(Response{Err: &Error{Msg: "Number of scalars and points must be equal"}})
+    return
+  }
+  scalars := make([]*big.Int, len(msmParams.S))
+  points := make([]Point, len(msmParams.A))
+  for i := range msmParams.S {
+    if msmParams.S[i] == nil {
+      encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Missing scalar at index %d", i)}})
+      return
+    }
+    scalars[i], err = NewBigInt(msmParams.S[i].V, err)
+    points[i], err = curve.NewPoint(msmParams.A[i], err)
+  }
+  ans, err := CurveMultiScalarMult(curve, scalars, points, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: ans.CurvePoint()})
 }
 
 func ECHashToPoint(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
-  var text Text
-  err := ReadContentsIntoStruct(r, &text)
+  var hashToPointInputs HashToPointInputs
+  err := RequireBN256(r)
+  if err == nil {
+    err = ReadContentsIntoStruct(r, &hashToPointInputs)
+  }
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  A := new(bn256.G1).Hash(text.T)
+  switch hashToPointInputs.Mode {
+  case "", "legacy":
+    A, ctr, err := LegacyHashToPoint(hashToPointInputs.T)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    encoder.Encode(Response{P: NewCurvePoint(A), Counter: &ctr})
+  case "rfc9380":
+    A, err := HashToCurve([]byte(hashToPointInputs.T), []byte(hashToPointInputs.DST))
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    encoder.Encode(Response{P: NewCurvePoint(A)})
+  default:
+    encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Unknown hash to point mode: %s", hashToPointInputs.Mode)}})
+  }
+}
+
+func ECG2Add(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryG2OpParams BinaryG2OpParams
+  err := ReadContentsIntoStruct(r, &binaryG2OpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := NewECPointG2(binaryG2OpParams.A, err)
+  B, err := NewECPointG2(binaryG2OpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := new(bn256.G2).Add(A, B)
+  encoder.Encode(Response{P2: NewG2Point(ans)})
+}
+
+func ECG2Sub(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryG2OpParams BinaryG2OpParams
+  err := ReadContentsIntoStruct(r, &binaryG2OpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := NewECPointG2(binaryG2OpParams.A, err)
+  B, err := NewECPointG2(binaryG2OpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := new(bn256.G2).Add(A, B.Neg(B))
+  encoder.Encode(Response{P2: NewG2Point(ans)})
+}
+
+func ECG2Mul(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var scalarG2OpParams ScalarG2OpParams
+  err := ReadContentsIntoStruct(r, &scalarG2OpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if scalarG2OpParams.S == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing scalar s"}})
+    return
+  }
+  s, err := NewBigInt(scalarG2OpParams.S.V, err)
+  A, err := NewECPointG2(scalarG2OpParams.A, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := new(bn256.G2).ScalarMult(A, s)
+  encoder.Encode(Response{P2: NewG2Point(ans)})
+}
+
+func ECG2BaseMul(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var number Number
+  err := ReadContentsIntoStruct(r, &number)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  s, err := NewBigInt(number.V, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := new(bn256.G2).ScalarBaseMult(s)
+  encoder.Encode(Response{P2: NewG2Point(ans)})
+}
+
+// Unmarshalling a G2 point checks both that it lies on the twist and that it
+// is in the order-q subgroup, so a point that parses is a valid G2 element
+func ECG2Validate(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var g2Point G2Point
+  err := ReadContentsIntoStruct(r, &g2Point)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  curvePoint := NewCurvePoint(A)
-  encoder.Encode(Response{P: curvePoint})
+  _, err = NewECPointG2(&g2Point, err)
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", err == nil)})
 }
@@ -0,0 +1,238 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+)
+
+// the /fr/ and /fp/ routes share these handlers, the first one works modulo
+// the bn256 group order and the second one modulo the field prime
+
+var ErrNonCanonical = errors.New("Field elements must be in the range [0, modulus)")
+
+func NewFieldElement(num string, modulus *big.Int, err error) (*big.Int, error) {
+  a, err := NewBigInt(num, err)
+  if err != nil {
+    return nil, err
+  }
+  if a.Sign() < 0 || a.Cmp(modulus) >= 0 {
+    return nil, ErrNonCanonical
+  }
+  return a, nil
+}
+
+func NewFieldElements(nums []*Number, modulus *big.Int, err error) ([]*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  elements := make([]*big.Int, len(nums))
+  for i := range nums {
+    if nums[i] == nil {
+      return nil, fmt.Errorf("Missing number at index %d", i)
+    }
+    elements[i], err = NewFieldElement(nums[i].V, modulus, err)
+  }
+  return elements, err
+}
+
+// Montgomery's trick: one inversion and 3(n-1) multiplications
+func BatchInvert(nums []*big.Int, modulus *big.Int, err error) ([]*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  prefix := make([]*big.Int, len(nums))
+  acc := big.NewInt(1)
+  for i, a := range nums {
+    if IsZero(a) {
+      return nil, fmt.Errorf("Zero has no inverse (index %d)", i)
+    }
+    prefix[i] = acc
+    acc = new(big.Int).Mul(acc, a)
+    acc.Mod(acc, modulus)
+  }
+  inv := new(big.Int).ModInverse(acc, modulus)
+  if inv == nil {
+    return nil, errors.New("Product is not invertible")
+  }
+  invs := make([]*big.Int, len(nums))
+  for i := len(nums)-1; i >= 0; i-- {
+    invs[i] = new(big.Int).Mul(inv, prefix[i])
+    invs[i].Mod(invs[i], modulus)
+    inv = new(big.Int).Mul(inv, nums[i])
+    inv.Mod(inv, modulus)
+  }
+  return invs, nil
+}
+
+func FieldAdd(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var binaryOpParams BinaryOpParams
+    err := ReadContentsIntoStruct(r, &binaryOpParams)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(binaryOpParams.A, modulus, err)
+    b, err := NewFieldElement(binaryOpParams.B, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).Add(a, b)
+    encoder.Encode(Response{Num: NewNumber(ans.Mod(ans, modulus))})
+  }
+}
+
+func FieldSub(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var binaryOpParams BinaryOpParams
+    err := ReadContentsIntoStruct(r, &binaryOpParams)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(binaryOpParams.A, modulus, err)
+    b, err := NewFieldElement(binaryOpParams.B, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).Sub(a, b)
+    encoder.Encode(Response{Num: NewNumber(ans.Mod(ans, modulus))})
+  }
+}
+
+func FieldMul(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var binaryOpParams BinaryOpParams
+    err := ReadContentsIntoStruct(r, &binaryOpParams)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(binaryOpParams.A, modulus, err)
+    b, err := NewFieldElement(binaryOpParams.B, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).Mul(a, b)
+    encoder.Encode(Response{Num: NewNumber(ans.Mod(ans, modulus))})
+  }
+}
+
+func FieldInv(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var number Number
+    err := ReadContentsIntoStruct(r, &number)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(number.V, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).ModInverse(a, modulus)
+    if ans == nil {
+      encoder.Encode(Response{Err: &Error{Msg: "Zero has no inverse"}})
+      return
+    }
+    encoder.Encode(Response{Num: NewNumber(ans)})
+  }
+}
+
+func FieldNeg(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var number Number
+    err := ReadContentsIntoStruct(r, &number)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(number.V, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).Neg(a)
+    encoder.Encode(Response{Num: NewNumber(ans.Mod(ans, modulus))})
+  }
+}
+
+// the exponent b is an integer, not a field element, so it is not range
+// checked; a negative exponent inverts a first
+func FieldPow(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var binaryOpParams BinaryOpParams
+    err := ReadContentsIntoStruct(r, &binaryOpParams)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(binaryOpParams.A, modulus, err)
+    b, err := NewBigInt(binaryOpParams.B, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).Exp(a, b, modulus)
+    if ans == nil {
+      encoder.Encode(Response{Err: &Error{Msg: "Zero has no inverse"}})
+      return
+    }
+    encoder.Encode(Response{Num: NewNumber(ans)})
+  }
+}
+
+func FieldSqrt(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var number Number
+    err := ReadContentsIntoStruct(r, &number)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    a, err := NewFieldElement(number.V, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    ans := new(big.Int).ModSqrt(a, modulus)
+    if ans == nil {
+      encoder.Encode(Response{Err: &Error{Msg: "Not a quadratic residue"}})
+      return
+    }
+    encoder.Encode(Response{Num: NewNumber(ans)})
+  }
+}
+
+func FieldBatchInv(modulus *big.Int) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var numbers Numbers
+    err := ReadContentsIntoStruct(r, &numbers)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    nums, err := NewFieldElements(numbers.V, modulus, err)
+    invs, err := BatchInvert(nums, modulus, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    encoder.Encode(Response{Nums: NewNumbers(invs)})
+  }
+}
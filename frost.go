@@ -0,0 +1,185 @@
+package main
+
+import (
+  "bytes"
+  "crypto/rand"
+  "errors"
+  "fmt"
+  "math/big"
+  "sort"
+  "github.com/rynobey/bn256"
+  "github.com/ethereum/go-ethereum/crypto/sha3"
+)
+
+// FROST threshold Schnorr signatures producing ordinary signatures for
+// VerifySchnorrSignature. The group key P = x*G has x Shamir shared, e.g. with
+// /vss/feldman/split/ where P is the first commitment. Each signer i picks
+// nonces (d_i, e_i) and publishes D_i = d_i*G and E_i = e_i*G; once the
+// commitments of all signers are known, every signer computes
+//   rho_j = keccak256("FROST/rho" || j || P || keccak256(M) || commitments) mod q
+//   R     = sum_j D_j + rho_j*E_j
+//   e     = the usual Schnorr challenge for (M, P, R)
+//   z_i   = d_i + e_i*rho_i + lambda_i*x_i*e
+// and s = sum_i z_i, so that s*G - e*P = R. The aggregator checks every z_i
+// against the signer's public share Y_i = x_i*G before summing
+
+type SignerCommitment struct {
+  I   int
+  D   *bn256.G1
+  E   *bn256.G1
+}
+
+func GenerateNoncePair() (*big.Int, *big.Int, error) {
+  d, err := rand.Int(rand.Reader, bn256.Order)
+  if err != nil {
+    return nil, nil, err
+  }
+  e, err := rand.Int(rand.Reader, bn256.Order)
+  if err != nil {
+    return nil, nil, err
+  }
+  return d, e, nil
+}
+
+// parses the commitments and sorts them by signer index
+func NewSignerCommitments(commitments []*NonceCommitment, err error) ([]*SignerCommitment, error) {
+  if err != nil {
+    return nil, err
+  }
+  if len(commitments) == 0 {
+    return nil, errors.New("At least one nonce commitment is required")
+  }
+  signers := make([]*SignerCommitment, len(commitments))
+  for i, commitment := range commitments {
+    if commitment == nil {
+      return nil, fmt.Errorf("Missing nonce commitment at index %d", i)
+    }
+    if commitment.I < 1 {
+      return nil, fmt.Errorf("Signer index must be positive (index %d)", i)
+    }
+    D, err := NewECPointFromCurvePoint(commitment.D, nil)
+    E, err := NewECPointFromCurvePoint(commitment.E, err)
+    if err != nil {
+      return nil, err
+    }
+    signers[i] = &SignerCommitment{I: commitment.I, D: D, E: E}
+  }
+  sort.Slice(signers, func(a, b int) (bool) { return signers[a].I < signers[b].I })
+  for i := 1; i < len(signers); i++ {
+    if signers[i].I == signers[i-1].I {
+      return nil, fmt.Errorf("Duplicate signer index %d", signers[i].I)
+    }
+  }
+  return signers, nil
+}
+
+func FrostBindingFactors(P *bn256.G1, M string, signers []*SignerCommitment) ([]*big.Int) {
+  var encoded bytes.Buffer
+  for _, signer := range signers {
+    D := NewCurvePoint(signer.D)
+    E := NewCurvePoint(signer.E)
+    encoded.WriteString(fmt.Sprintf("0x%064x%s%s%s%s", signer.I, D.X, D.Y, E.X, E.Y))
+  }
+  // binds rho to the group key as in RFC 9591; M is hashed first so that every
+  // part of the input has a fixed length
+  key := NewCurvePoint(P)
+  hM := sha3.NewKeccak256()
+  hM.Write([]byte(M))
+  digest := hM.Sum(nil)
+  rhos := make([]*big.Int, len(signers))
+  for j, signer := range signers {
+    h := sha3.NewKeccak256()
+    h.Write([]byte(fmt.Sprintf("FROST/rho0x%064x%s%s", signer.I, key.X, key.Y)))
+    h.Write(digest)
+    h.Write(encoded.Bytes())
+    rhos[j] = new(big.Int).SetBytes(h.Sum(nil))
+    rhos[j].Mod(rhos[j], bn256.Order)
+  }
+  return rhos
+}
+
+// the group commitment R and the challenge e
+func FrostChallenge(P *bn256.G1, M string, signers []*SignerCommitment, rhos []*big.Int) (*bn256.G1, *big.Int) {
+  R := new(bn256.G1).ScalarBaseMult(new(big.Int))
+  for j, signer := range signers {
+    R = new(bn256.G1).Add(R, signer.D)
+    R = new(bn256.G1).Add(R, new(bn256.G1).ScalarMult(signer.E, rhos[j]))
+  }
+  e := SchnorrChallenge(M, &BN256Point{G1: P}, &BN256Point{G1: R})
+  return R, e
+}
+
+func FrostSignerIndices(signers []*SignerCommitment) ([]*big.Int) {
+  xs := make([]*big.Int, len(signers))
+  for j, signer := range signers {
+    xs[j] = big.NewInt(int64(signer.I))
+  }
+  return xs
+}
+
+func FrostPartialSign(i int, x *big.Int, d *big.Int, e *big.Int, P *bn256.G1, M string, signers []*SignerCommitment, err error) (*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  self := -1
+  for j, signer := range signers {
+    if signer.I == i {
+      self = j
+    }
+  }
+  if self < 0 {
+    return nil, fmt.Errorf("Signer %d has no nonce commitment", i)
+  }
+  // catches nonces that do not belong to the published commitment
+  if !bytes.Equal(new(bn256.G1).ScalarBaseMult(d).Marshal(), signers[self].D.Marshal()) ||
+    !bytes.Equal(new(bn256.G1).ScalarBaseMult(e).Marshal(), signers[self].E.Marshal()) {
+    return nil, errors.New("Nonces do not match the signer's commitment")
+  }
+  rhos := FrostBindingFactors(P, M, signers)
+  _, c := FrostChallenge(P, M, signers, rhos)
+  lambdas, err := LagrangeCoefficientsAtZero(FrostSignerIndices(signers), err)
+  if err != nil {
+    return nil, err
+  }
+  z := new(big.Int).Mul(e, rhos[self])
+  z.Add(z, d)
+  z.Add(z, new(big.Int).Mul(new(big.Int).Mul(lambdas[self], x), c))
+  return z.Mod(z, bn256.Order), nil
+}
+
+// R, e and s = sum z_i, after checking z_i*G == D_i + rho_i*E_i + lambda_i*e*Y_i
+// for every signer so that a bad partial signature is traced to its signer
+func FrostAggregate(P *bn256.G1, M string, signers []*SignerCommitment, partials map[int]*big.Int, shares map[int]*bn256.G1, err error) (*bn256.G1, *big.Int, *big.Int, error) {
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  if len(partials) != len(signers) {
+    return nil, nil, nil, errors.New("Every committed signer must provide exactly one partial signature")
+  }
+  rhos := FrostBindingFactors(P, M, signers)
+  R, e := FrostChallenge(P, M, signers, rhos)
+  lambdas, err := LagrangeCoefficientsAtZero(FrostSignerIndices(signers), err)
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  s := new(big.Int)
+  for j, signer := range signers {
+    z, ok := partials[signer.I]
+    if !ok {
+      return nil, nil, nil, fmt.Errorf("Missing partial signature from signer %d", signer.I)
+    }
+    Y, ok := shares[signer.I]
+    if !ok {
+      return nil, nil, nil, fmt.Errorf("Missing public share from signer %d", signer.I)
+    }
+    c := new(big.Int).Mul(lambdas[j], e)
+    expected := new(bn256.G1).Add(signer.D, new(bn256.G1).ScalarMult(signer.E, rhos[j]))
+    expected = new(bn256.G1).Add(expected, new(bn256.G1).ScalarMult(Y, c.Mod(c, bn256.Order)))
+    if !bytes.Equal(new(bn256.G1).ScalarBaseMult(z).Marshal(), expected.Marshal()) {
+      return nil, nil, nil, fmt.Errorf("Invalid partial signature from signer %d", signer.I)
+    }
+    s.Add(s, z)
+  }
+  s.Mod(s, bn256.Order)
+  return R, e, s, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+func FrostCommit(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var frostCommitInputs FrostCommitInputs
+  err := ReadContentsIntoStruct(r, &frostCommitInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if frostCommitInputs.I < 1 {
+    encoder.Encode(Response{Err: &Error{Msg: "Signer index must be positive"}})
+    return
+  }
+  d, e, err := GenerateNoncePair()
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  nonces := &NoncePair{D: fmt.Sprintf("0x%064x", d), E: fmt.Sprintf("0x%064x", e)}
+  commitment := &NonceCommitment{
+    I: frostCommitInputs.I,
+    D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d)),
+    E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e)),
+  }
+  encoder.Encode(Response{Nonces: nonces, NonceCommitment: commitment})
+}
+
+func FrostSign(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var frostSignInputs FrostSignInputs
+  err := ReadContentsIntoStruct(r, &frostSignInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if frostSignInputs.Nonces == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing nonces"}})
+    return
+  }
+  x, err := NewFieldElement(frostSignInputs.Share, bn256.Order, err)
+  d, err := NewFieldElement(frostSignInputs.Nonces.D, bn256.Order, err)
+  e, err := NewFieldElement(frostSignInputs.Nonces.E, bn256.Order, err)
+  P, err := NewECPointFromCurvePoint(frostSignInputs.P, err)
+  signers, err := NewSignerCommitments(frostSignInputs.Commitments, err)
+  z, err := FrostPartialSign(frostSignInputs.I, x, d, e, P, frostSignInputs.M, signers, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  Y := NewCurvePoint(new(bn256.G1).ScalarBaseMult(x))
+  encoder.Encode(Response{Partial: &PartialSignature{I: frostSignInputs.I, Z: fmt.Sprintf("0x%064x", z), Y: Y}})
+}
+
+func FrostAggregateSignature(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var frostAggregateInputs FrostAggregateInputs
+  err := ReadContentsIntoStruct(r, &frostAggregateInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  P, err := NewECPointFromCurvePoint(frostAggregateInputs.P, err)
+  signers, err := NewSignerCommitments(frostAggregateInputs.Commitments, err)
+  partials := make(map[int]*big.Int)
+  shares := make(map[int]*bn256.G1)
+  for i, partial := range frostAggregateInputs.Partials {
+    if partial == nil {
+      err = fmt.Errorf("Missing partial signature at index %d", i)
+      break
+    }
+    if _, ok := partials[partial.I]; ok {
+      err = fmt.Errorf("Duplicate partial signature from signer %d", partial.I)
+      break
+    }
+    if partial.Y == nil {
+      err = fmt.Errorf("Missing public share from signer %d", partial.I)
+      break
+    }
+    partials[partial.I], err = NewFieldElement(partial.Z, bn256.Order, err)
+    shares[partial.I], err = NewECPointFromCurvePoint(partial.Y, err)
+  }
+  M := frostAggregateInputs.M
+  R, E, S, err := FrostAggregate(P, M, signers, partials, shares, err)
+  isValid, err := VerifySchnorrSignature(P, M, E, S, err)
+  if err == nil && !isValid {
+    err = errors.New("Aggregate signature does not verify, the public shares do not match the group public key")
+  }
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Sig: &SchnorrSignature{P: NewCurvePoint(P), K: NewCurvePoint(R), M: M, E: fmt.Sprintf("0x%064x", E), S: fmt.Sprintf("0x%064x", S)}})
+}
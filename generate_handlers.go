@@ -2,6 +2,8 @@ package main
 
 import (
   "fmt"
+  "errors"
+  "crypto/rand"
   "net/http"
   "encoding/json"
   "math/big"
@@ -38,29 +40,166 @@ func GenerateCommitment(w http.ResponseWriter, r *http.Request) {
   }
   b, err := NewBigInt(commitmentInputs.B, err)
   v, err := NewBigInt(commitmentInputs.V, err)
-  H, err := NewECPoint(commitmentInputs.H.X, commitmentInputs.H.Y, err)
-  G, err := NewECPoint(commitmentInputs.G.X, commitmentInputs.G.Y, err)
+  G, H, err := PedersenGenerators(commitmentInputs.G, commitmentInputs.H, err)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  bbHH := new(bn256.G1).ScalarMult(H, b)
-  vvGG := new(bn256.G1).ScalarMult(G, v)
-  C := bbHH.Add(bbHH, vvGG)
+  C := PedersenCommit(G, H, v, b)
   commitment := NewCurvePoint(C)
   encoder.Encode(Response{P: commitment})
 }
 
+func GenerateVectorCommitment(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var vectorCommitmentInputs VectorCommitmentInputs
+  err := ReadContentsIntoStruct(r, &vectorCommitmentInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if len(vectorCommitmentInputs.V) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "At least one value is required"}})
+    return
+  }
+  vs := make([]*big.Int, len(vectorCommitmentInputs.V))
+  for i := range vs {
+    vs[i], err = NewBigInt(vectorCommitmentInputs.V[i], err)
+  }
+  b, err := NewBigInt(vectorCommitmentInputs.B, err)
+  Gs, H, err := VectorPedersenGenerators(vectorCommitmentInputs.Gs, vectorCommitmentInputs.H, len(vs), err)
+  C, err := VectorPedersenCommit(Gs, H, vs, b, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: NewCurvePoint(C)})
+}
+
 func GenerateSchnorr(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   var generateSchnorrInputs GenerateSchnorrInputs
-  err := ReadContentsIntoStruct(r, &generateSchnorrInputs)
+  err = ReadContentsIntoStruct(r, &generateSchnorrInputs)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
   X, err := NewBigInt(generateSchnorrInputs.Priv, err)
   M := generateSchnorrInputs.M
-  P_out, K_out, M_out, E_out, S_out, _ := GenerateSchnorrSignature(M, X, err)
-  encoder.Encode(Response{Sig: &SchnorrSignature{P: NewCurvePoint(P_out), K: NewCurvePoint(K_out), M: M_out, E: fmt.Sprintf("0x%064x", E_out), S: fmt.Sprintf("0x%064x", S_out)}})
+  P_out, K_out, M_out, E_out, S_out, err := GenerateCurveSchnorrSignature(curve, M, X, generateSchnorrInputs.Nonce, generateSchnorrInputs.Scheme, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Sig: &SchnorrSignature{P: P_out.CurvePoint(), K: K_out.CurvePoint(), M: M_out, E: fmt.Sprintf("0x%064x", E_out), S: fmt.Sprintf("0x%064x", S_out), Scheme: generateSchnorrInputs.Scheme}})
+}
+
+func GenerateBLSKeyPair(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  x, err := rand.Int(rand.Reader, bn256.Order)
+  if err == nil && IsZero(x) {
+    err = errors.New("Generated a zero private key")
+  }
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  P := new(bn256.G2).ScalarBaseMult(x)
+  encoder.Encode(Response{Num: NewNumber(x), P2: NewG2Point(P)})
+}
+
+func GenerateBLS(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var generateBLSInputs GenerateBLSInputs
+  err := ReadContentsIntoStruct(r, &generateBLSInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  X, err := NewBigInt(generateBLSInputs.Priv, err)
+  M := generateBLSInputs.M
+  P, S, err := GenerateBLSSignature(M, X, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{BLSSig: &BLSSignature{P: NewG2Point(P), M: M, S: NewCurvePoint(S)}})
+}
+
+func GenerateBLSPoP(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var generateBLSPoPInputs GenerateBLSPoPInputs
+  err := ReadContentsIntoStruct(r, &generateBLSPoPInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  X, err := NewBigInt(generateBLSPoPInputs.Priv, err)
+  P, S, err := GenerateBLSPossessionProof(X, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{BLSPoP: &BLSPoP{P: NewG2Point(P), S: NewCurvePoint(S)}})
+}
+
+// an aggregate key verifies one message signed by all its parts, so every key
+// must come with a proof of possession; otherwise a rogue key p' = x'*g2 - p_1
+// would let its owner sign alone for p_1 + p'
+func AggregateBLS(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var aggregateBLSInputs AggregateBLSInputs
+  err := ReadContentsIntoStruct(r, &aggregateBLSInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if len(aggregateBLSInputs.Sigs) == 0 && len(aggregateBLSInputs.Keys) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "Nothing to aggregate"}})
+    return
+  }
+  var res Response
+  if len(aggregateBLSInputs.Sigs) > 0 {
+    S := new(bn256.G1).ScalarBaseMult(new(big.Int))
+    for _, sig := range aggregateBLSInputs.Sigs {
+      Si, err := NewECPointFromCurvePoint(sig, nil)
+      if err != nil {
+        encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+        return
+      }
+      S = new(bn256.G1).Add(S, Si)
+    }
+    res.P = NewCurvePoint(S)
+  }
+  if len(aggregateBLSInputs.Keys) > 0 {
+    if len(aggregateBLSInputs.PoPs) != len(aggregateBLSInputs.Keys) {
+      encoder.Encode(Response{Err: &Error{Msg: "Every public key needs a proof of possession"}})
+      return
+    }
+    P := new(bn256.G2).ScalarBaseMult(new(big.Int))
+    for i, key := range aggregateBLSInputs.Keys {
+      Pi, err := NewECPointG2(key, nil)
+      pop, err := NewECPointFromCurvePoint(aggregateBLSInputs.PoPs[i], err)
+      isValid, err := VerifyBLSPossessionProof(Pi, pop, err)
+      if err == nil && !isValid {
+        err = fmt.Errorf("Invalid proof of possession for key %d", i)
+      }
+      if err != nil {
+        encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+        return
+      }
+      P = new(bn256.G2).Add(P, Pi)
+    }
+    if IsInfinityG2(P) {
+      encoder.Encode(Response{Err: &Error{Msg: "Aggregate public key is the point at infinity"}})
+      return
+    }
+    res.P2 = NewG2Point(P)
+  }
+  encoder.Encode(res)
 }
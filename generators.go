@@ -0,0 +1,95 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// generators with no known discrete log relative to G = (1, 2), derived from a
+// label with RFC 9380 hash_to_curve so that anybody can recompute them
+
+const GeneratorDST = "ECC-API-GENERATORS-V01-with-BN254G1_XMD:SHA-256_SVDW_RO_"
+
+// every default generator is one hash_to_curve, so vectors are kept short
+const MaxCommitmentValues = 1 << 12
+
+func NUMSGenerator(label string, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  return HashToCurve([]byte(label), []byte(GeneratorDST))
+}
+
+// G and H for Pedersen commitments; either can be overridden by the caller
+func PedersenGenerators(g *CurvePoint, h *CurvePoint, err error) (*bn256.G1, *bn256.G1, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  if g != nil {
+    G, err = NewECPointFromCurvePoint(g, err)
+  }
+  var H *bn256.G1
+  if h != nil {
+    H, err = NewECPointFromCurvePoint(h, err)
+  } else {
+    H, err = NUMSGenerator("pedersen/H", err)
+  }
+  if err != nil {
+    return nil, nil, err
+  }
+  return G, H, nil
+}
+
+// G_0, ..., G_(n-1) and H for vector Pedersen commitments; by default G_0 and
+// H are the G and H of PedersenGenerators, so that a single value commitment
+// is the same either way, and G_i for i > 0 is the hash of "pedersen/G/i"
+func VectorPedersenGenerators(gs []*CurvePoint, h *CurvePoint, n int, err error) ([]*bn256.G1, *bn256.G1, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  if n > MaxCommitmentValues {
+    return nil, nil, fmt.Errorf("Number of values must be at most %d", MaxCommitmentValues)
+  }
+  G, H, err := PedersenGenerators(nil, h, err)
+  Gs := make([]*bn256.G1, n)
+  if gs != nil {
+    if len(gs) != n {
+      return nil, nil, errors.New("Number of values and generators must be equal")
+    }
+    for i := range gs {
+      Gs[i], err = NewECPointFromCurvePoint(gs[i], err)
+    }
+  } else {
+    for i := range Gs {
+      if i == 0 {
+        Gs[i] = G
+      } else {
+        Gs[i], err = NUMSGenerator(fmt.Sprintf("pedersen/G/%d", i), err)
+      }
+    }
+  }
+  if err != nil {
+    return nil, nil, err
+  }
+  return Gs, H, nil
+}
+
+// v*G + b*H
+func PedersenCommit(G *bn256.G1, H *bn256.G1, v *big.Int, b *big.Int) (*bn256.G1) {
+  vG := new(bn256.G1).ScalarMult(G, v)
+  bH := new(bn256.G1).ScalarMult(H, b)
+  return new(bn256.G1).Add(vG, bH)
+}
+
+// sum_i v_i*G_i + b*H
+func VectorPedersenCommit(Gs []*bn256.G1, H *bn256.G1, vs []*big.Int, b *big.Int, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  scalars := append(append([]*big.Int{}, vs...), b)
+  points := append(append([]*bn256.G1{}, Gs...), H)
+  return MultiScalarMult(scalars, points, err)
+}
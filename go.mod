@@ -0,0 +1,8 @@
+module github.com/WrightKD/ECC-API
+
+go 1.20
+
+require (
+	github.com/ethereum/go-ethereum v1.8.20
+	github.com/gorilla/mux v1.8.1
+)
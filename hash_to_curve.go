@@ -0,0 +1,179 @@
+package main
+
+import (
+  "crypto/sha256"
+  "errors"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// hash_to_curve for BN254 G1 as described in RFC 9380, with suite
+// BN254G1_XMD:SHA-256_SVDW_RO_: expand_message_xmd with SHA-256, two field
+// elements of L = 48 bytes each, the Shallue-van de Woestijne map with Z = 1,
+// and no cofactor clearing since G1 has cofactor 1
+
+var svdwZ = big.NewInt(1)
+var svdwC1, svdwC2, svdwC3, svdwC4 = SVDWConstants()
+
+// c1 = g(Z), c2 = -Z / 2, c3 = sqrt(-g(Z) * 3 * Z^2) with sgn0(c3) = 0 and
+// c4 = -4 * g(Z) / (3 * Z^2), for the curve y^2 = g(x) = x^3 + 3
+func SVDWConstants() (*big.Int, *big.Int, *big.Int, *big.Int) {
+  p := bn256.P
+  gZ := CurveRHS(svdwZ)
+  threeZZ := new(big.Int).Mul(big.NewInt(3), new(big.Int).Mul(svdwZ, svdwZ))
+  c1 := gZ
+  c2 := new(big.Int).Mul(new(big.Int).Neg(svdwZ), new(big.Int).ModInverse(big.NewInt(2), p))
+  c2.Mod(c2, p)
+  c3 := new(big.Int).Mul(new(big.Int).Neg(gZ), threeZZ)
+  c3 = new(big.Int).ModSqrt(c3.Mod(c3, p), p)
+  if c3.Bit(0) == 1 {
+    c3.Sub(p, c3)
+  }
+  c4 := new(big.Int).Mul(big.NewInt(-4), gZ)
+  c4.Mul(c4, new(big.Int).ModInverse(threeZZ, p))
+  c4.Mod(c4, p)
+  return c1, c2, c3, c4
+}
+
+// x^3 + 3 mod p
+func CurveRHS(x *big.Int) (*big.Int) {
+  return BN256Params.RHS(x)
+}
+
+func ExpandMessageXMD(msg []byte, dst []byte, lenInBytes int) ([]byte, error) {
+  const bInBytes = sha256.Size
+  const sInBytes = sha256.BlockSize
+  if len(dst) > 255 {
+    h := sha256.New()
+    h.Write([]byte("H2C-OVERSIZE-DST-"))
+    h.Write(dst)
+    dst = h.Sum(nil)
+  }
+  ell := (lenInBytes + bInBytes - 1)/bInBytes
+  if ell > 255 || lenInBytes > 65535 {
+    return nil, errors.New("Requested output of expand_message_xmd is too long")
+  }
+  dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+  h := sha256.New()
+  h.Write(make([]byte, sInBytes))
+  h.Write(msg)
+  h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+  h.Write(dstPrime)
+  b0 := h.Sum(nil)
+  h.Reset()
+  h.Write(b0)
+  h.Write([]byte{1})
+  h.Write(dstPrime)
+  bi := h.Sum(nil)
+  uniformBytes := append([]byte{}, bi...)
+  for i := 2; i <= ell; i++ {
+    xored := make([]byte, bInBytes)
+    for j := range xored {
+      xored[j] = b0[j] ^ bi[j]
+    }
+    h.Reset()
+    h.Write(xored)
+    h.Write([]byte{byte(i)})
+    h.Write(dstPrime)
+    bi = h.Sum(nil)
+    uniformBytes = append(uniformBytes, bi...)
+  }
+  return uniformBytes[:lenInBytes], nil
+}
+
+func HashToField(msg []byte, dst []byte, count int) ([]*big.Int, error) {
+  const L = 48
+  uniformBytes, err := ExpandMessageXMD(msg, dst, count*L)
+  if err != nil {
+    return nil, err
+  }
+  u := make([]*big.Int, count)
+  for i := range u {
+    u[i] = new(big.Int).SetBytes(uniformBytes[i*L:(i+1)*L])
+    u[i].Mod(u[i], bn256.P)
+  }
+  return u, nil
+}
+
+func IsSquare(x *big.Int) (bool) {
+  return big.Jacobi(x, bn256.P) >= 0
+}
+
+// the straight-line SVDW map from RFC 9380, appendix F.1
+func MapToCurveSVDW(u *big.Int) (*bn256.G1, error) {
+  p := bn256.P
+  one := big.NewInt(1)
+  tv1 := new(big.Int).Mul(u, u)
+  tv1.Mul(tv1, svdwC1).Mod(tv1, p)
+  tv2 := new(big.Int).Add(one, tv1)
+  tv2.Mod(tv2, p)
+  tv1.Sub(one, tv1).Mod(tv1, p)
+  tv3 := new(big.Int).Mul(tv1, tv2)
+  tv3.Mod(tv3, p)
+  if IsZero(tv3) {
+    tv3.SetInt64(0)
+  } else {
+    tv3.ModInverse(tv3, p)
+  }
+  tv4 := new(big.Int).Mul(u, tv1)
+  tv4.Mul(tv4, tv3).Mul(tv4, svdwC3).Mod(tv4, p)
+  x1 := new(big.Int).Sub(svdwC2, tv4)
+  x1.Mod(x1, p)
+  x2 := new(big.Int).Add(svdwC2, tv4)
+  x2.Mod(x2, p)
+  x3 := new(big.Int).Mul(tv2, tv2)
+  x3.Mul(x3, tv3).Mod(x3, p)
+  x3.Mul(x3, x3).Mul(x3, svdwC4).Add(x3, svdwZ).Mod(x3, p)
+  x := x3
+  if IsSquare(CurveRHS(x1)) {
+    x = x1
+  } else if IsSquare(CurveRHS(x2)) {
+    x = x2
+  }
+  y := new(big.Int).ModSqrt(CurveRHS(x), p)
+  if y == nil {
+    return nil, errors.New("SVDW map produced a point off the curve")
+  }
+  if u.Bit(0) != y.Bit(0) {
+    y.Sub(p, y)
+  }
+  return NewECPointFromCoordinates(x, y, nil)
+}
+
+func HashToCurve(msg []byte, dst []byte) (*bn256.G1, error) {
+  if len(dst) == 0 {
+    return nil, errors.New("A domain separation tag is required")
+  }
+  u, err := HashToField(msg, dst, 2)
+  if err != nil {
+    return nil, err
+  }
+  Q0, err := MapToCurveSVDW(u[0])
+  if err != nil {
+    return nil, err
+  }
+  Q1, err := MapToCurveSVDW(u[1])
+  if err != nil {
+    return nil, err
+  }
+  return new(bn256.G1).Add(Q0, Q1), nil
+}
+
+// bn256's G1.Hash tries x = sha256(m || ctr) mod p for ctr = 0, 1, ... until
+// x^3 + 3 is a square; the counter that was used is recovered from the result
+// so that the point can be recomputed (and checked) elsewhere
+func LegacyHashToPoint(m string) (*bn256.G1, int, error) {
+  P := new(bn256.G1).Hash(m)
+  if P == nil {
+    return nil, 0, errors.New("Failed to hash message to a curve point")
+  }
+  x := new(big.Int).SetBytes(P.Marshal()[:32])
+  for ctr := 0; ctr < 256; ctr++ {
+    digest := sha256.Sum256(append([]byte(m), byte(ctr)))
+    candidate := new(big.Int).SetBytes(digest[:])
+    if candidate.Mod(candidate, bn256.P).Cmp(x) == 0 {
+      return P, ctr, nil
+    }
+  }
+  return nil, 0, errors.New("Unable to determine the counter used by the legacy hash")
+}
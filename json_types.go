@@ -1,6 +1,7 @@
 package main
 
 import (
+  "encoding/json"
   "github.com/rynobey/bn256"
   "math/big"
   "fmt"
@@ -9,8 +10,28 @@ import (
 type Response struct {
   Text  string              `json:"text,omitempty"`
   Num   *Number             `json:"number,omitempty"`
+  Nums  []*Number           `json:"numbers,omitempty"`
+  Poly  []*Number           `json:"poly,omitempty"`
+  Rem   []*Number           `json:"remainder,omitempty"`
+  Shares []*Share           `json:"shares,omitempty"`
+  Commitments []*CurvePoint `json:"commitments,omitempty"`
+  Nonces  *NoncePair        `json:"nonces,omitempty"`
+  NonceCommitment *NonceCommitment `json:"commitment,omitempty"`
+  Partial *PartialSignature `json:"partial,omitempty"`
   P     *CurvePoint         `json:"curvepoint,omitempty"`
+  P2    *G2Point            `json:"g2point,omitempty"`
+  GT    *GTElement          `json:"gt,omitempty"`
+  Verdict *PointVerdict     `json:"verdict,omitempty"`
+  Valid []bool              `json:"valid,omitempty"`
+  Counter *int              `json:"counter,omitempty"`
   Sig   *SchnorrSignature   `json:"sig,omitempty"`
+  RangeProof *RangeProof    `json:"rangeproof,omitempty"`
+  DLogProof *DLogProof      `json:"dlogproof,omitempty"`
+  DLEQProof *DLEQProof      `json:"dleqproof,omitempty"`
+  CommitmentProof *CommitmentProof `json:"commitmentproof,omitempty"`
+  BLSSig  *BLSSignature     `json:"blssig,omitempty"`
+  BLSPoP  *BLSPoP           `json:"blspop,omitempty"`
+  Results []json.RawMessage `json:"results,omitempty"`
   Err   *Error              `json:"error,omitempty"`
 }
 
@@ -18,6 +39,15 @@ type Text struct {
   T   string        `json:"t"`
 }
 
+// Mode is "legacy" (the default) for bn256's try-and-increment hash, or
+// "rfc9380" for BN254G1_XMD:SHA-256_SVDW_RO_ with DST as the domain
+// separation tag
+type HashToPointInputs struct {
+  T     string      `json:"t"`
+  Mode  string      `json:"mode,omitempty"`
+  DST   string      `json:"dst,omitempty"`
+}
+
 type BinaryEcOpParams struct {
   A   *CurvePoint   `json:"a"`
   B   *CurvePoint   `json:"b"`
@@ -28,9 +58,24 @@ type ScalarEcOpParams struct {
   A   *CurvePoint   `json:"a"`
 }
 
+// Reason is one of "out_of_range", "not_on_curve" or "infinity" when the
+// point is not valid
+type PointVerdict struct {
+  Valid   bool      `json:"valid"`
+  Reason  string    `json:"reason,omitempty"`
+  Msg     string    `json:"msg,omitempty"`
+}
+
+type MSMParams struct {
+  S   []*Number     `json:"s"`
+  A   []*CurvePoint `json:"a"`
+}
+
+// either X and Y or, for a compressed point, C
 type CurvePoint struct {
-  X   string      `json:"x"`
-  Y   string      `json:"y"`
+  X   string      `json:"x,omitempty"`
+  Y   string      `json:"y,omitempty"`
+  C   string      `json:"c,omitempty"`
 }
 
 func NewCurvePoint(P *bn256.G1) (*CurvePoint) {
@@ -40,6 +85,63 @@ func NewCurvePoint(P *bn256.G1) (*CurvePoint) {
   return &CurvePoint{X: x, Y: y}
 }
 
+type BinaryG2OpParams struct {
+  A   *G2Point    `json:"a"`
+  B   *G2Point    `json:"b"`
+}
+
+type ScalarG2OpParams struct {
+  S   *Number     `json:"s"`
+  A   *G2Point    `json:"a"`
+}
+
+// Fp2 elements are written as im * i + re, imaginary part first, in the same
+// order that bn256 (and Ethereum's precompiles) marshal them
+type Fp2 struct {
+  Im  string      `json:"im"`
+  Re  string      `json:"re"`
+}
+
+type G2Point struct {
+  X   *Fp2        `json:"x"`
+  Y   *Fp2        `json:"y"`
+}
+
+func NewG2Point(P *bn256.G2) (*G2Point) {
+  marshalledPoint := P.Marshal()
+  x := &Fp2{Im: fmt.Sprintf("0x%064x", marshalledPoint[0:32]), Re: fmt.Sprintf("0x%064x", marshalledPoint[32:64])}
+  y := &Fp2{Im: fmt.Sprintf("0x%064x", marshalledPoint[64:96]), Re: fmt.Sprintf("0x%064x", marshalledPoint[96:128])}
+  return &G2Point{X: x, Y: y}
+}
+
+// GT elements are the 12 Fp coefficients of the Fp12 value, in the order
+// that bn256 marshals them
+type GTElement struct {
+  C   []string    `json:"c"`
+}
+
+func NewGTElement(P *bn256.GT) (*GTElement) {
+  marshalledElement := P.Marshal()
+  coefficients := make([]string, len(marshalledElement)/32)
+  for i := range coefficients {
+    coefficients[i] = fmt.Sprintf("0x%064x", marshalledElement[32*i:32*(i+1)])
+  }
+  return &GTElement{C: coefficients}
+}
+
+type PairingInputs struct {
+  A   *CurvePoint   `json:"a"`
+  B   *G2Point      `json:"b"`
+}
+
+// Pairs may be given either as JSON points or, in Input, as the raw calldata
+// of Ethereum's ecPairing precompile: k 192-byte blocks of
+// (x, y, x_im, x_re, y_im, y_re)
+type PairingCheckInputs struct {
+  Pairs   []*PairingInputs  `json:"pairs,omitempty"`
+  Input   string            `json:"input,omitempty"`
+}
+
 type BinaryOpParams struct {
   A   string      `json:"a"`
   B   string      `json:"b"`
@@ -58,9 +160,142 @@ type CommitmentInputs struct {
   G   *CurvePoint   `json:"g"`
 }
 
+type VectorCommitmentInputs struct {
+  V   []string        `json:"v"`
+  B   string          `json:"b"`
+  Gs  []*CurvePoint   `json:"gs,omitempty"`
+  H   *CurvePoint     `json:"h,omitempty"`
+}
+
+// a commitment and its claimed opening
+type CommitmentOpening struct {
+  C   *CurvePoint     `json:"c"`
+  V   []string        `json:"v"`
+  B   string          `json:"b"`
+  Gs  []*CurvePoint   `json:"gs,omitempty"`
+  H   *CurvePoint     `json:"h,omitempty"`
+}
+
+type CommitmentListInputs struct {
+  Commitments []*CurvePoint `json:"commitments"`
+}
+
+type CommitmentScaleInputs struct {
+  C   *CurvePoint     `json:"c"`
+  K   string          `json:"k"`
+}
+
+type CommitmentBalanceInputs struct {
+  Inputs  []*CurvePoint `json:"inputs"`
+  Outputs []*CurvePoint `json:"outputs"`
+  Excess  string        `json:"excess"`
+  H       *CurvePoint   `json:"h,omitempty"`
+}
+
+// N is the number of bits, 64 if not given
+type RangeProofInputs struct {
+  V   []string        `json:"v"`
+  B   []string        `json:"b"`
+  N   int             `json:"n,omitempty"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  H   *CurvePoint     `json:"h,omitempty"`
+}
+
+type RangeProofVerifyInputs struct {
+  Commitments []*CurvePoint `json:"commitments"`
+  N   int             `json:"n,omitempty"`
+  Proof *RangeProof   `json:"rangeproof"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  H   *CurvePoint     `json:"h,omitempty"`
+}
+
+type RangeProof struct {
+  A     *CurvePoint   `json:"a"`
+  S     *CurvePoint   `json:"s"`
+  T1    *CurvePoint   `json:"t1"`
+  T2    *CurvePoint   `json:"t2"`
+  TauX  string        `json:"taux"`
+  Mu    string        `json:"mu"`
+  T     string        `json:"t"`
+  L     []*CurvePoint `json:"l"`
+  R     []*CurvePoint `json:"r"`
+  IPA   string        `json:"ipa"`
+  IPB   string        `json:"ipb"`
+}
+
+// G is the generator (1, 2) if not given
+type DLogProofInputs struct {
+  X   string          `json:"x"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  Context string      `json:"context"`
+}
+
+type DLogProofVerifyInputs struct {
+  P   *CurvePoint     `json:"p"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  Context string      `json:"context"`
+  Proof *DLogProof    `json:"dlogproof"`
+}
+
+type DLogProof struct {
+  R   *CurvePoint     `json:"r"`
+  S   string          `json:"s"`
+}
+
+type DLEQProofInputs struct {
+  X   *Number         `json:"x"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  H   *CurvePoint     `json:"h"`
+  Context string      `json:"context"`
+}
+
+type DLEQProofVerifyInputs struct {
+  G   *CurvePoint     `json:"g,omitempty"`
+  H   *CurvePoint     `json:"h"`
+  Context string      `json:"context"`
+  Proof *DLEQProof    `json:"dleqproof"`
+}
+
+// A = x*G and B = x*H with the proof (C, S)
+type DLEQProof struct {
+  A   *CurvePoint     `json:"a"`
+  B   *CurvePoint     `json:"b"`
+  C   *Number         `json:"c"`
+  S   *Number         `json:"s"`
+}
+
+type Opening struct {
+  V   string          `json:"v"`
+  B   string          `json:"b"`
+}
+
+type CommitmentProofInputs struct {
+  Openings []*Opening `json:"openings"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  H   *CurvePoint     `json:"h,omitempty"`
+  Context string      `json:"context"`
+}
+
+type CommitmentProofVerifyInputs struct {
+  Commitments []*CurvePoint `json:"commitments"`
+  G   *CurvePoint     `json:"g,omitempty"`
+  H   *CurvePoint     `json:"h,omitempty"`
+  Context string      `json:"context"`
+  Proof *CommitmentProof `json:"commitmentproof"`
+}
+
+// SV is only part of an opening proof
+type CommitmentProof struct {
+  R   *CurvePoint     `json:"r"`
+  SV  string          `json:"sv,omitempty"`
+  SB  string          `json:"sb"`
+}
+
 type GenerateSchnorrInputs struct {
   Priv    string        `json:"priv"`
   M       string        `json:"m"`
+  Nonce   string        `json:"nonce,omitempty"`
+  Scheme  string        `json:"scheme,omitempty"`
 }
 
 type SchnorrSignature struct {
@@ -69,13 +304,177 @@ type SchnorrSignature struct {
   M   string        `json:"m"`
   E   string        `json:"e"`
   S   string        `json:"s"`
+  Scheme  string    `json:"scheme,omitempty"`
+}
+
+// secret nonces of one signer, never to be reused
+type NoncePair struct {
+  D   string        `json:"d"`
+  E   string        `json:"e"`
+}
+
+type NonceCommitment struct {
+  I   int           `json:"i,omitempty"`
+  D   *CurvePoint   `json:"d"`
+  E   *CurvePoint   `json:"e"`
+}
+
+type PartialSignature struct {
+  I   int           `json:"i"`
+  Z   string        `json:"z"`
+  Y   *CurvePoint   `json:"y,omitempty"`
+}
+
+type FrostCommitInputs struct {
+  I   int           `json:"i"`
+}
+
+type FrostSignInputs struct {
+  I           int                 `json:"i"`
+  Share       string              `json:"share"`
+  Nonces      *NoncePair          `json:"nonces"`
+  P           *CurvePoint         `json:"p"`
+  M           string              `json:"m"`
+  Commitments []*NonceCommitment  `json:"commitments"`
+}
+
+type FrostAggregateInputs struct {
+  P           *CurvePoint         `json:"p"`
+  M           string              `json:"m"`
+  Commitments []*NonceCommitment  `json:"commitments"`
+  Partials    []*PartialSignature `json:"partials"`
+}
+
+type MuSig2KeyAggInputs struct {
+  Keys        []*CurvePoint       `json:"keys"`
+}
+
+type MuSig2SignInputs struct {
+  Priv        string              `json:"priv"`
+  Nonces      *NoncePair          `json:"nonces"`
+  Keys        []*CurvePoint       `json:"keys"`
+  M           string              `json:"m"`
+  Commitments []*NonceCommitment  `json:"commitments"`
+}
+
+type MuSig2AggregateInputs struct {
+  Keys        []*CurvePoint       `json:"keys"`
+  M           string              `json:"m"`
+  Commitments []*NonceCommitment  `json:"commitments"`
+  Partials    []*PartialSignature `json:"partials"`
+}
+
+type GenerateBLSInputs struct {
+  Priv    string        `json:"priv"`
+  M       string        `json:"m"`
+}
+
+// BLS signatures live in G1 (S = x * H(m)) and public keys in G2 (P = x * g2)
+type BLSSignature struct {
+  P   *G2Point      `json:"p"`
+  M   string        `json:"m"`
+  S   *CurvePoint   `json:"s"`
+}
+
+// every key needs a proof of possession, in the same order
+type AggregateBLSInputs struct {
+  Sigs    []*CurvePoint   `json:"sigs,omitempty"`
+  Keys    []*G2Point      `json:"keys,omitempty"`
+  PoPs    []*CurvePoint   `json:"pops,omitempty"`
+}
+
+// proof of possession of the private key of P: S = x * HashToCurve(P)
+type BLSPoP struct {
+  P   *G2Point      `json:"p"`
+  S   *CurvePoint   `json:"s"`
+}
+
+type GenerateBLSPoPInputs struct {
+  Priv    string        `json:"priv"`
+}
+
+type AggregateBLSSignature struct {
+  P   []*G2Point    `json:"p"`
+  M   []string      `json:"m"`
+  S   *CurvePoint   `json:"s"`
+}
+
+// Op is a route path such as "/ec/add/"; Method defaults to POST when Params
+// are given and GET otherwise
+type BatchItem struct {
+  Op      string            `json:"op"`
+  Method  string            `json:"method,omitempty"`
+  Params  json.RawMessage   `json:"params,omitempty"`
 }
 
 type Number struct {
   V   string    `json:"v"`
 }
 
+type PolyParams struct {
+  Poly  []*Number   `json:"poly"`
+  X     *Number     `json:"x,omitempty"`
+  N     *Number     `json:"n,omitempty"`
+}
+
+type BinaryPolyParams struct {
+  A   []*Number   `json:"a"`
+  B   []*Number   `json:"b"`
+}
+
+type InterpolationParams struct {
+  Xs  []*Number   `json:"xs"`
+  Ys  []*Number   `json:"ys"`
+}
+
+type SplitInputs struct {
+  Secret  string    `json:"secret"`
+  T       int       `json:"t"`
+  N       int       `json:"n"`
+}
+
+type Share struct {
+  I   int       `json:"i"`
+  V   string    `json:"v"`
+  B   string    `json:"b,omitempty"`
+}
+
+type VSSSplitInputs struct {
+  Secret  string        `json:"secret"`
+  T       int           `json:"t"`
+  N       int           `json:"n"`
+  G       *CurvePoint   `json:"g,omitempty"`
+  H       *CurvePoint   `json:"h,omitempty"`
+}
+
+type VSSVerifyInputs struct {
+  Scheme      string          `json:"scheme"`
+  Share       *Share          `json:"share"`
+  Commitments []*CurvePoint   `json:"commitments"`
+  G           *CurvePoint     `json:"g,omitempty"`
+  H           *CurvePoint     `json:"h,omitempty"`
+}
+
+type CombineInputs struct {
+  Shares  []*Share  `json:"shares"`
+}
+
+type Numbers struct {
+  V   []*Number `json:"numbers"`
+}
+
+func NewNumbers(nums []*big.Int) ([]*Number) {
+  numbers := make([]*Number, len(nums))
+  for i := range nums {
+    numbers[i] = NewNumber(nums[i])
+  }
+  return numbers
+}
+
 func NewNumber(num *big.Int) (*Number) {
+  if num.Sign() < 0 {
+    return &Number{V: fmt.Sprintf("-0x%x", new(big.Int).Neg(num))}
+  }
   return &Number{V: fmt.Sprintf("0x%x", num)}
 }
 
@@ -4,13 +4,22 @@ import (
   "net/http"
   "encoding/json"
   "math/big"
-  "github.com/rynobey/bn256"
   "crypto/rand"
+  "fmt"
 )
 
 func CryptoRandBigInt(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
-  num, _ := rand.Int(rand.Reader, bn256.Order)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  num, err := rand.Int(rand.Reader, curve.Params().N)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   encoder.Encode(Response{Num: NewNumber(num)})
 }
 
@@ -67,6 +76,10 @@ func BigIntInvMod(w http.ResponseWriter, r *http.Request) {
     return
   }
   ans := new(big.Int).ModInverse(a, b)
+  if ans == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Number is not invertible"}})
+    return
+  }
   encoder.Encode(Response{Num: NewNumber(ans)})
 }
 
@@ -105,3 +118,212 @@ func BigIntMod(w http.ResponseWriter, r *http.Request) {
   ans := new(big.Int).Mod(a, b)
   encoder.Encode(Response{Num: NewNumber(ans)})
 }
+
+func BigIntSub(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := new(big.Int).Sub(a, b)
+  encoder.Encode(Response{Num: NewNumber(ans)})
+}
+
+func BigIntModExp(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var ternaryOpParams TernaryOpParams
+  err := ReadContentsIntoStruct(r, &ternaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(ternaryOpParams.A, err)
+  b, err := NewBigInt(ternaryOpParams.B, err)
+  c, err := NewBigInt(ternaryOpParams.C, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if c.Sign() <= 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "Modulus must be positive"}})
+    return
+  }
+  // a negative exponent uses the inverse of a, which must exist
+  ans := new(big.Int).Exp(a, b, c)
+  if ans == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Base is not invertible modulo c"}})
+    return
+  }
+  encoder.Encode(Response{Num: NewNumber(ans)})
+}
+
+// euclidean division: a = q*b + r with 0 <= r < |b|
+func BigIntDivMod(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if IsZero(b) {
+    encoder.Encode(Response{Err: &Error{Msg: "Division by zero"}})
+    return
+  }
+  q, m := new(big.Int).DivMod(a, b, new(big.Int))
+  encoder.Encode(Response{Nums: []*Number{NewNumber(q), NewNumber(m)}})
+}
+
+func BigIntGCD(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := new(big.Int).GCD(nil, nil, a, b)
+  encoder.Encode(Response{Num: NewNumber(ans)})
+}
+
+// g = gcd(a, b) together with x, y such that a*x + b*y = g
+func BigIntEGCD(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  x := new(big.Int)
+  y := new(big.Int)
+  g := new(big.Int).GCD(x, y, a, b)
+  encoder.Encode(Response{Nums: []*Number{NewNumber(g), NewNumber(x), NewNumber(y)}})
+}
+
+func BigIntCmp(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%d", a.Cmp(b))})
+}
+
+func BigIntSqrtMod(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if !IsPrime(b) || b.Bit(0) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "Modulus must be an odd prime"}})
+    return
+  }
+  ans := new(big.Int).ModSqrt(a, b)
+  if ans == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Not a quadratic residue"}})
+    return
+  }
+  encoder.Encode(Response{Num: NewNumber(ans)})
+}
+
+func BigIntLegendre(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if !IsPrime(b) || b.Bit(0) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "Modulus must be an odd prime"}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%d", big.Jacobi(a, b))})
+}
+
+func BigIntJacobi(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryOpParams BinaryOpParams
+  err := ReadContentsIntoStruct(r, &binaryOpParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(binaryOpParams.A, err)
+  b, err := NewBigInt(binaryOpParams.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if b.Sign() <= 0 || b.Bit(0) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "Modulus must be odd and positive"}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%d", big.Jacobi(a, b))})
+}
+
+func BigIntIsPrime(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var number Number
+  err := ReadContentsIntoStruct(r, &number)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewBigInt(number.V, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", IsPrime(a))})
+}
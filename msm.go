@@ -0,0 +1,88 @@
+package main
+
+import (
+  "errors"
+  "math/big"
+  "math/bits"
+  "github.com/rynobey/bn256"
+)
+
+// window size in bits for the bucket method; each window costs 2^c bucket
+// additions, so small inputs use a narrow window
+func MSMWindowSize(n int) (int) {
+  if n < 32 {
+    return 3
+  }
+  return bits.Len(uint(n)) - 2
+}
+
+// s_1 * P_1 + ... + s_n * P_n using Pippenger's bucket method: the scalars
+// are cut into c-bit windows, the points are added into one bucket per window
+// digit and the buckets are combined with a running sum, so each window costs
+// n + 2^(c+1) additions and c doublings instead of a full scalar
+// multiplication per term
+func CurveMultiScalarMult(curve Curve, scalars []*big.Int, points []Point, err error) (Point, error) {
+  if err != nil {
+    return nil, err
+  }
+  if len(scalars) != len(points) {
+    return nil, errors.New("Number of scalars and points must be equal")
+  }
+  c := MSMWindowSize(len(points))
+  reduced := make([]*big.Int, len(scalars))
+  maxBits := 0
+  for i, s := range scalars {
+    reduced[i] = new(big.Int).Mod(s, curve.Params().N)
+    if reduced[i].BitLen() > maxBits {
+      maxBits = reduced[i].BitLen()
+    }
+  }
+  result := curve.Identity()
+  numWindows := (maxBits + c - 1)/c
+  for w := numWindows-1; w >= 0; w-- {
+    for i := 0; i < c; i++ {
+      result = result.Add(result)
+    }
+    buckets := make([]Point, (1 << uint(c)) - 1)
+    for i, s := range reduced {
+      digit := 0
+      for b := c-1; b >= 0; b-- {
+        digit = (digit << 1) | int(s.Bit(w*c + b))
+      }
+      if digit == 0 {
+        continue
+      }
+      if buckets[digit-1] == nil {
+        buckets[digit-1] = points[i]
+      } else {
+        buckets[digit-1] = buckets[digit-1].Add(points[i])
+      }
+    }
+    // running sum: bucket j ends up added j+1 times
+    sum := curve.Identity()
+    windowSum := curve.Identity()
+    for j := len(buckets)-1; j >= 0; j-- {
+      if buckets[j] != nil {
+        sum = sum.Add(buckets[j])
+      }
+      windowSum = windowSum.Add(sum)
+    }
+    result = result.Add(windowSum)
+  }
+  return result, nil
+}
+
+func MultiScalarMult(scalars []*big.Int, points []*bn256.G1, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  wrapped := make([]Point, len(points))
+  for i := range points {
+    wrapped[i] = &BN256Point{G1: points[i]}
+  }
+  ans, err := CurveMultiScalarMult(BN256, scalars, wrapped, err)
+  if err != nil {
+    return nil, err
+  }
+  return ans.(*BN256Point).G1, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+  "bytes"
+  "errors"
+  "fmt"
+  "math/big"
+  "github.com/rynobey/bn256"
+  "github.com/ethereum/go-ethereum/crypto/sha3"
+)
+
+// MuSig2 multi-signatures producing ordinary signatures for
+// VerifySchnorrSignature under the aggregate key
+//   L      = keccak256("MuSig2/L" || X_1 || ... || X_n)
+//   a_i    = keccak256("MuSig2/agg" || L || X_i) mod q
+//   X      = sum_i a_i*X_i
+// Every signer publishes two nonce commitments (D_i, E_i) and with
+//   b      = keccak256("MuSig2/noncecoef" || X || D || E || M) mod q
+//   R      = D + b*E, for D = sum_i D_i and E = sum_i E_i
+//   e      = the usual Schnorr challenge for (M, X, R)
+// signs with s_i = d_i + b*e_i + e*a_i*x_i, and s = sum_i s_i
+
+func MuSig2KeyCoefficients(keys []*bn256.G1, err error) ([]*big.Int, *bn256.G1, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  if len(keys) == 0 {
+    return nil, nil, errors.New("At least one public key is required")
+  }
+  encoded := make([][]byte, len(keys))
+  h := sha3.NewKeccak256()
+  h.Write([]byte("MuSig2/L"))
+  for i, key := range keys {
+    encoded[i] = key.Marshal()
+    for j := 0; j < i; j++ {
+      if bytes.Equal(encoded[i], encoded[j]) {
+        return nil, nil, fmt.Errorf("Duplicate public key at index %d", i)
+      }
+    }
+    h.Write(encoded[i])
+  }
+  L := h.Sum(nil)
+  coeffs := make([]*big.Int, len(keys))
+  for i := range keys {
+    h := sha3.NewKeccak256()
+    h.Write([]byte("MuSig2/agg"))
+    h.Write(L)
+    h.Write(encoded[i])
+    coeffs[i] = new(big.Int).SetBytes(h.Sum(nil))
+    coeffs[i].Mod(coeffs[i], bn256.Order)
+  }
+  X, err := MultiScalarMult(coeffs, keys, err)
+  if err != nil {
+    return nil, nil, err
+  }
+  return coeffs, X, nil
+}
+
+// R and the nonce coefficient b
+func MuSig2Nonce(X *bn256.G1, M string, nonces []*SignerCommitment) (*bn256.G1, *big.Int) {
+  D := new(bn256.G1).ScalarBaseMult(new(big.Int))
+  E := new(bn256.G1).ScalarBaseMult(new(big.Int))
+  for _, nonce := range nonces {
+    D = new(bn256.G1).Add(D, nonce.D)
+    E = new(bn256.G1).Add(E, nonce.E)
+  }
+  h := sha3.NewKeccak256()
+  h.Write([]byte("MuSig2/noncecoef"))
+  h.Write(X.Marshal())
+  h.Write(D.Marshal())
+  h.Write(E.Marshal())
+  h.Write([]byte(M))
+  b := new(big.Int).SetBytes(h.Sum(nil))
+  b.Mod(b, bn256.Order)
+  R := new(bn256.G1).Add(D, new(bn256.G1).ScalarMult(E, b))
+  return R, b
+}
+
+func NewNonceCommitments(commitments []*NonceCommitment, n int, err error) ([]*SignerCommitment, error) {
+  if err != nil {
+    return nil, err
+  }
+  if len(commitments) != n {
+    return nil, errors.New("Every signer must provide exactly one nonce commitment")
+  }
+  nonces := make([]*SignerCommitment, len(commitments))
+  for i, commitment := range commitments {
+    if commitment == nil {
+      return nil, fmt.Errorf("Missing nonce commitment at index %d", i)
+    }
+    D, err := NewECPointFromCurvePoint(commitment.D, nil)
+    E, err := NewECPointFromCurvePoint(commitment.E, err)
+    if err != nil {
+      return nil, err
+    }
+    nonces[i] = &SignerCommitment{I: commitment.I, D: D, E: E}
+  }
+  return nonces, nil
+}
+
+// the partial signature and the position of the signer's key in keys
+func MuSig2PartialSign(x *big.Int, d *big.Int, e *big.Int, keys []*bn256.G1, M string, nonces []*SignerCommitment, err error) (*big.Int, int, error) {
+  coeffs, X, err := MuSig2KeyCoefficients(keys, err)
+  if err != nil {
+    return nil, 0, err
+  }
+  self := -1
+  own := new(bn256.G1).ScalarBaseMult(x).Marshal()
+  for i, key := range keys {
+    if bytes.Equal(key.Marshal(), own) {
+      self = i
+    }
+  }
+  if self < 0 {
+    return nil, 0, errors.New("The signer's public key is not in the list of keys")
+  }
+  D := new(bn256.G1).ScalarBaseMult(d).Marshal()
+  E := new(bn256.G1).ScalarBaseMult(e).Marshal()
+  found := false
+  for _, nonce := range nonces {
+    if bytes.Equal(nonce.D.Marshal(), D) && bytes.Equal(nonce.E.Marshal(), E) {
+      found = true
+    }
+  }
+  if !found {
+    return nil, 0, errors.New("The signer's nonce commitment is not in the list of commitments")
+  }
+  R, b := MuSig2Nonce(X, M, nonces)
+  c := SchnorrChallenge(M, &BN256Point{G1: X}, &BN256Point{G1: R})
+  s := new(big.Int).Mul(b, e)
+  s.Add(s, d)
+  s.Add(s, new(big.Int).Mul(new(big.Int).Mul(c, coeffs[self]), x))
+  return s.Mod(s, bn256.Order), self, nil
+}
+
+// the aggregate key X, R, e and s = sum s_i
+func MuSig2Aggregate(keys []*bn256.G1, M string, nonces []*SignerCommitment, partials []*big.Int, err error) (*bn256.G1, *bn256.G1, *big.Int, *big.Int, error) {
+  _, X, err := MuSig2KeyCoefficients(keys, err)
+  if err != nil {
+    return nil, nil, nil, nil, err
+  }
+  if len(partials) != len(keys) {
+    return nil, nil, nil, nil, errors.New("Every signer must provide exactly one partial signature")
+  }
+  R, _ := MuSig2Nonce(X, M, nonces)
+  e := SchnorrChallenge(M, &BN256Point{G1: X}, &BN256Point{G1: R})
+  s := new(big.Int)
+  for _, partial := range partials {
+    s.Add(s, partial)
+  }
+  return X, R, e, s.Mod(s, bn256.Order), nil
+}
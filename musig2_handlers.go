@@ -0,0 +1,104 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+func MuSig2KeyAgg(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var muSig2KeyAggInputs MuSig2KeyAggInputs
+  err := ReadContentsIntoStruct(r, &muSig2KeyAggInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  keys, err := NewECPointsFromCurvePoints(muSig2KeyAggInputs.Keys, err)
+  coeffs, X, err := MuSig2KeyCoefficients(keys, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: NewCurvePoint(X), Nums: NewNumbers(coeffs)})
+}
+
+func MuSig2GenerateNonce(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  d, e, err := GenerateNoncePair()
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  nonces := &NoncePair{D: fmt.Sprintf("0x%064x", d), E: fmt.Sprintf("0x%064x", e)}
+  commitment := &NonceCommitment{
+    D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d)),
+    E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e)),
+  }
+  encoder.Encode(Response{Nonces: nonces, NonceCommitment: commitment})
+}
+
+func MuSig2Sign(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var muSig2SignInputs MuSig2SignInputs
+  err := ReadContentsIntoStruct(r, &muSig2SignInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if muSig2SignInputs.Nonces == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing nonces"}})
+    return
+  }
+  x, err := NewFieldElement(muSig2SignInputs.Priv, bn256.Order, err)
+  d, err := NewFieldElement(muSig2SignInputs.Nonces.D, bn256.Order, err)
+  e, err := NewFieldElement(muSig2SignInputs.Nonces.E, bn256.Order, err)
+  keys, err := NewECPointsFromCurvePoints(muSig2SignInputs.Keys, err)
+  nonces, err := NewNonceCommitments(muSig2SignInputs.Commitments, len(keys), err)
+  s, i, err := MuSig2PartialSign(x, d, e, keys, muSig2SignInputs.M, nonces, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Partial: &PartialSignature{I: i+1, Z: fmt.Sprintf("0x%064x", s)}})
+}
+
+func MuSig2AggregateSignature(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var muSig2AggregateInputs MuSig2AggregateInputs
+  err := ReadContentsIntoStruct(r, &muSig2AggregateInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  keys, err := NewECPointsFromCurvePoints(muSig2AggregateInputs.Keys, err)
+  nonces, err := NewNonceCommitments(muSig2AggregateInputs.Commitments, len(keys), err)
+  partials := make([]*big.Int, len(muSig2AggregateInputs.Partials))
+  seen := make(map[int]bool)
+  for i, partial := range muSig2AggregateInputs.Partials {
+    if partial == nil {
+      err = fmt.Errorf("Missing partial signature at index %d", i)
+      break
+    }
+    if seen[partial.I] {
+      err = fmt.Errorf("Duplicate partial signature from signer %d", partial.I)
+      break
+    }
+    seen[partial.I] = true
+    partials[i], err = NewFieldElement(partial.Z, bn256.Order, err)
+  }
+  M := muSig2AggregateInputs.M
+  X, R, E, S, err := MuSig2Aggregate(keys, M, nonces, partials, err)
+  isValid, err := VerifySchnorrSignature(X, M, E, S, err)
+  if err == nil && !isValid {
+    err = errors.New("Aggregate signature does not verify, at least one partial signature is invalid")
+  }
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Sig: &SchnorrSignature{P: NewCurvePoint(X), K: NewCurvePoint(R), M: M, E: fmt.Sprintf("0x%064x", E), S: fmt.Sprintf("0x%064x", S)}})
+}
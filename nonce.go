@@ -0,0 +1,95 @@
+package main
+
+import (
+  "crypto/hmac"
+  "crypto/rand"
+  "crypto/sha256"
+  "errors"
+  "fmt"
+  "math/big"
+)
+
+// how the Schnorr nonce k is chosen: "random" draws it from crypto/rand,
+// "deterministic" derives it from the private key and the message as in
+// RFC 6979 with HMAC-SHA-256, and "hedged" does the same with 32 fresh random
+// bytes as additional data (RFC 6979 section 3.6), so that a broken random
+// source degrades to deterministic nonces instead of leaking the key. The
+// domain (curve and challenge scheme) is always part of the additional data,
+// so signing the same message with the same key under two schemes never
+// reuses k
+func SchnorrNonce(N *big.Int, X *big.Int, M string, mode string, domain string) (*big.Int, error) {
+  if IsZero(new(big.Int).Mod(X, N)) {
+    return nil, errors.New("Private key must be non-zero mod the group order")
+  }
+  switch mode {
+  case "", "random":
+    return rand.Int(rand.Reader, N)
+  case "deterministic":
+    h := sha256.Sum256([]byte(M))
+    return RFC6979Nonce(N, X, h[:], []byte(domain)), nil
+  case "hedged":
+    extra := make([]byte, 32)
+    _, err := rand.Read(extra)
+    if err != nil {
+      return nil, err
+    }
+    h := sha256.Sum256([]byte(M))
+    return RFC6979Nonce(N, X, h[:], append(extra, domain...)), nil
+  default:
+    return nil, fmt.Errorf("Unknown nonce mode: %s", mode)
+  }
+}
+
+// the leftmost bitlen(N) bits of b as an integer
+func RFC6979BitsToInt(b []byte, N *big.Int) (*big.Int) {
+  v := new(big.Int).SetBytes(b)
+  if excess := len(b)*8 - N.BitLen(); excess > 0 {
+    v.Rsh(v, uint(excess))
+  }
+  return v
+}
+
+// v must fit in rlen bytes
+func RFC6979IntToOctets(v *big.Int, rlen int) ([]byte) {
+  out := make([]byte, rlen)
+  b := v.Bytes()
+  copy(out[rlen-len(b):], b)
+  return out
+}
+
+func RFC6979Nonce(N *big.Int, X *big.Int, hash []byte, extra []byte) (*big.Int) {
+  rlen := (N.BitLen() + 7)/8
+  mac := func(key []byte, parts ...[]byte) ([]byte) {
+    m := hmac.New(sha256.New, key)
+    for _, part := range parts {
+      m.Write(part)
+    }
+    return m.Sum(nil)
+  }
+  // the key is reduced mod N first (RFC 6979 section 2.3.3), so that longer
+  // or negative keys are encoded as the key actually used to sign
+  x := RFC6979IntToOctets(new(big.Int).Mod(X, N), rlen)
+  h := RFC6979IntToOctets(new(big.Int).Mod(RFC6979BitsToInt(hash, N), N), rlen)
+  V := make([]byte, sha256.Size)
+  for i := range V {
+    V[i] = 0x01
+  }
+  K := make([]byte, sha256.Size)
+  K = mac(K, V, []byte{0x00}, x, h, extra)
+  V = mac(K, V)
+  K = mac(K, V, []byte{0x01}, x, h, extra)
+  V = mac(K, V)
+  for {
+    var T []byte
+    for len(T) < rlen {
+      V = mac(K, V)
+      T = append(T, V...)
+    }
+    k := RFC6979BitsToInt(T[:rlen], N)
+    if k.Sign() > 0 && k.Cmp(N) < 0 {
+      return k
+    }
+    K = mac(K, V, []byte{0x00})
+    V = mac(K, V)
+  }
+}
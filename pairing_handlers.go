@@ -0,0 +1,43 @@
+package main
+
+import (
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "github.com/rynobey/bn256"
+)
+
+func Pairing(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var pairingInputs PairingInputs
+  err := ReadContentsIntoStruct(r, &pairingInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, err := NewECPointFromCurvePoint(pairingInputs.A, err)
+  B, err := NewECPointG2(pairingInputs.B, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  ans := bn256.Pair(A, B)
+  encoder.Encode(Response{GT: NewGTElement(ans)})
+}
+
+func PairingCheck(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var pairingCheckInputs PairingCheckInputs
+  err := ReadContentsIntoStruct(r, &pairingCheckInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  A, B, err := NewPairingPoints(pairingCheckInputs, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  isIdentity := bn256.PairingCheck(A, B)
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isIdentity)})
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// polynomials over Fr are coefficient slices, lowest degree first
+
+// r - 1 = 2^28 * t with t odd, and 5 generates the multiplicative group of Fr,
+// so 5^t has order exactly 2^28
+const FrTwoAdicity = 28
+var FrGenerator = big.NewInt(5)
+
+// products at least this long are multiplied with the NTT
+const PolyMulNTTThreshold = 64
+
+// a primitive n-th root of unity for n a power of two up to 2^28
+func FrRootOfUnity(n int, err error) (*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  if n <= 0 || n & (n - 1) != 0 {
+    return nil, errors.New("Size must be a power of two")
+  }
+  if n > 1 << FrTwoAdicity {
+    return nil, fmt.Errorf("Size must be at most 2^%d", FrTwoAdicity)
+  }
+  exp := new(big.Int).Sub(bn256.Order, big.NewInt(1))
+  exp.Div(exp, big.NewInt(int64(n)))
+  return new(big.Int).Exp(FrGenerator, exp, bn256.Order), nil
+}
+
+// Horner's rule
+func PolyEval(poly []*big.Int, x *big.Int) (*big.Int) {
+  ans := new(big.Int)
+  for i := len(poly)-1; i >= 0; i-- {
+    ans.Mul(ans, x)
+    ans.Add(ans, poly[i])
+    ans.Mod(ans, bn256.Order)
+  }
+  return ans
+}
+
+func PolyMul(a []*big.Int, b []*big.Int, err error) ([]*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  if len(a) == 0 || len(b) == 0 {
+    return []*big.Int{}, nil
+  }
+  size := len(a) + len(b) - 1
+  if size < PolyMulNTTThreshold {
+    ans := make([]*big.Int, size)
+    for i := range ans {
+      ans[i] = new(big.Int)
+    }
+    for i := range a {
+      for j := range b {
+        ans[i+j].Add(ans[i+j], new(big.Int).Mul(a[i], b[j]))
+      }
+    }
+    for i := range ans {
+      ans[i].Mod(ans[i], bn256.Order)
+    }
+    return ans, nil
+  }
+  n := 1
+  for n < size {
+    n <<= 1
+  }
+  aEvals, err := NTT(PolyPad(a, n), false, err)
+  bEvals, err := NTT(PolyPad(b, n), false, err)
+  if err != nil {
+    return nil, err
+  }
+  for i := range aEvals {
+    aEvals[i].Mul(aEvals[i], bEvals[i])
+    aEvals[i].Mod(aEvals[i], bn256.Order)
+  }
+  ans, err := NTT(aEvals, true, err)
+  if err != nil {
+    return nil, err
+  }
+  return ans[:size], nil
+}
+
+// copy of poly padded with zero coefficients up to length n
+func PolyPad(poly []*big.Int, n int) ([]*big.Int) {
+  padded := make([]*big.Int, n)
+  for i := range padded {
+    if i < len(poly) {
+      padded[i] = new(big.Int).Set(poly[i])
+    } else {
+      padded[i] = new(big.Int)
+    }
+  }
+  return padded
+}
+
+// poly = q * (x^n - 1) + r with deg(r) < n; every coefficient c_i with i >= n
+// moves to q_(i-n) and is added back onto c_(i-n), from the top down
+func PolyDivideVanishing(poly []*big.Int, n int, err error) ([]*big.Int, []*big.Int, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  if n <= 0 {
+    return nil, nil, errors.New("Degree of the vanishing polynomial must be positive")
+  }
+  rem := PolyPad(poly, len(poly))
+  if len(poly) <= n {
+    return []*big.Int{}, rem, nil
+  }
+  q := make([]*big.Int, len(poly) - n)
+  for i := len(poly)-1; i >= n; i-- {
+    q[i-n] = rem[i]
+    rem[i-n].Add(rem[i-n], rem[i])
+    rem[i-n].Mod(rem[i-n], bn256.Order)
+  }
+  return q, rem[:n], nil
+}
+
+// Lagrange interpolation: with M(x) = (x - x_0)...(x - x_(k-1)) the result is
+// the sum of y_i / M'(x_i) * M(x) / (x - x_i)
+func PolyInterpolate(xs []*big.Int, ys []*big.Int, err error) ([]*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  if len(xs) != len(ys) {
+    return nil, errors.New("Number of x and y values must be equal")
+  }
+  k := len(xs)
+  M := []*big.Int{big.NewInt(1)}
+  for _, x := range xs {
+    M, _ = PolyMul(M, []*big.Int{new(big.Int).Sub(bn256.Order, x), big.NewInt(1)}, nil)
+  }
+  denoms := make([]*big.Int, k)
+  for i := range xs {
+    denoms[i] = big.NewInt(1)
+    for j := range xs {
+      if i != j {
+        denoms[i].Mul(denoms[i], new(big.Int).Sub(xs[i], xs[j]))
+        denoms[i].Mod(denoms[i], bn256.Order)
+      }
+    }
+    if IsZero(denoms[i]) {
+      return nil, fmt.Errorf("Duplicate x value at index %d", i)
+    }
+  }
+  invs, err := BatchInvert(denoms, bn256.Order, err)
+  if err != nil {
+    return nil, err
+  }
+  ans := PolyPad(nil, k)
+  for i := range xs {
+    // synthetic division of M by (x - x_i)
+    scale := new(big.Int).Mul(ys[i], invs[i])
+    carry := new(big.Int)
+    for j := k; j >= 1; j-- {
+      carry.Mul(carry, xs[i]).Add(carry, M[j]).Mod(carry, bn256.Order)
+      ans[j-1].Add(ans[j-1], new(big.Int).Mul(carry, scale))
+      ans[j-1].Mod(ans[j-1], bn256.Order)
+    }
+  }
+  return ans, nil
+}
+
+// evaluations at 1, w, w^2, ... for the len(values)-th root of unity w, or the
+// coefficients back from those evaluations when inverse is set
+func NTT(values []*big.Int, inverse bool, err error) ([]*big.Int, error) {
+  n := len(values)
+  w, err := FrRootOfUnity(n, err)
+  if err != nil {
+    return nil, err
+  }
+  if inverse {
+    w.ModInverse(w, bn256.Order)
+  }
+  ans := make([]*big.Int, n)
+  logN := 0
+  for 1 << uint(logN) < n {
+    logN++
+  }
+  for i := range values {
+    rev := 0
+    for b := 0; b < logN; b++ {
+      rev |= ((i >> uint(b)) & 1) << uint(logN-1-b)
+    }
+    ans[rev] = new(big.Int).Set(values[i])
+  }
+  for size := 2; size <= n; size <<= 1 {
+    wStep := new(big.Int).Exp(w, big.NewInt(int64(n/size)), bn256.Order)
+    for start := 0; start < n; start += size {
+      wi := big.NewInt(1)
+      for j := 0; j < size/2; j++ {
+        u := ans[start+j]
+        v := new(big.Int).Mul(ans[start+j+size/2], wi)
+        v.Mod(v, bn256.Order)
+        ans[start+j] = new(big.Int).Add(u, v)
+        ans[start+j].Mod(ans[start+j], bn256.Order)
+        ans[start+j+size/2] = new(big.Int).Sub(u, v)
+        ans[start+j+size/2].Mod(ans[start+j+size/2], bn256.Order)
+        wi.Mul(wi, wStep).Mod(wi, bn256.Order)
+      }
+    }
+  }
+  if inverse {
+    nInv := new(big.Int).ModInverse(big.NewInt(int64(n)), bn256.Order)
+    for i := range ans {
+      ans[i].Mul(ans[i], nInv).Mod(ans[i], bn256.Order)
+    }
+  }
+  return ans, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+  "errors"
+  "net/http"
+  "encoding/json"
+  "github.com/rynobey/bn256"
+)
+
+func PolyEvaluate(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var polyParams PolyParams
+  err := ReadContentsIntoStruct(r, &polyParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if polyParams.X == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing evaluation point x"}})
+    return
+  }
+  poly, err := NewFieldElements(polyParams.Poly, bn256.Order, err)
+  x, err := NewFieldElement(polyParams.X.V, bn256.Order, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Num: NewNumber(PolyEval(poly, x))})
+}
+
+func PolyMultiply(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var binaryPolyParams BinaryPolyParams
+  err := ReadContentsIntoStruct(r, &binaryPolyParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  a, err := NewFieldElements(binaryPolyParams.A, bn256.Order, err)
+  b, err := NewFieldElements(binaryPolyParams.B, bn256.Order, err)
+  ans, err := PolyMul(a, b, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Poly: NewNumbers(ans)})
+}
+
+func PolyDivide(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var polyParams PolyParams
+  err := ReadContentsIntoStruct(r, &polyParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if polyParams.N == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing degree n of the vanishing polynomial"}})
+    return
+  }
+  poly, err := NewFieldElements(polyParams.Poly, bn256.Order, err)
+  n, err := NewBigInt(polyParams.N.V, err)
+  if err == nil && !n.IsInt64() {
+    err = errors.New("Degree of the vanishing polynomial is too large")
+  }
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  q, rem, err := PolyDivideVanishing(poly, int(n.Int64()), err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Poly: NewNumbers(q), Rem: NewNumbers(rem)})
+}
+
+func PolyInterpolation(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var interpolationParams InterpolationParams
+  err := ReadContentsIntoStruct(r, &interpolationParams)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  xs, err := NewFieldElements(interpolationParams.Xs, bn256.Order, err)
+  ys, err := NewFieldElements(interpolationParams.Ys, bn256.Order, err)
+  ans, err := PolyInterpolate(xs, ys, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Poly: NewNumbers(ans)})
+}
+
+func PolyNTT(inverse bool) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var polyParams PolyParams
+    err := ReadContentsIntoStruct(r, &polyParams)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    values, err := NewFieldElements(polyParams.Poly, bn256.Order, err)
+    ans, err := NTT(values, inverse, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    encoder.Encode(Response{Poly: NewNumbers(ans)})
+  }
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+  "errors"
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+func NewRangeProof(proof *RangeProofData) (*RangeProof) {
+  Ls := make([]*CurvePoint, len(proof.L))
+  Rs := make([]*CurvePoint, len(proof.R))
+  for k := range proof.L {
+    Ls[k] = NewCurvePoint(proof.L[k])
+    Rs[k] = NewCurvePoint(proof.R[k])
+  }
+  return &RangeProof{
+    A: NewCurvePoint(proof.A),
+    S: NewCurvePoint(proof.S),
+    T1: NewCurvePoint(proof.T1),
+    T2: NewCurvePoint(proof.T2),
+    TauX: fmt.Sprintf("0x%064x", proof.TauX),
+    Mu: fmt.Sprintf("0x%064x", proof.Mu),
+    T: fmt.Sprintf("0x%064x", proof.T),
+    L: Ls,
+    R: Rs,
+    IPA: fmt.Sprintf("0x%064x", proof.IPA),
+    IPB: fmt.Sprintf("0x%064x", proof.IPB),
+  }
+}
+
+func NewRangeProofData(proof *RangeProof, err error) (*RangeProofData, error) {
+  if err != nil {
+    return nil, err
+  }
+  if proof == nil {
+    return nil, errors.New("Missing range proof")
+  }
+  data := &RangeProofData{}
+  data.A, err = NewECPointFromCurvePoint(proof.A, err)
+  data.S, err = NewECPointFromCurvePoint(proof.S, err)
+  data.T1, err = NewECPointFromCurvePoint(proof.T1, err)
+  data.T2, err = NewECPointFromCurvePoint(proof.T2, err)
+  data.TauX, err = NewFieldElement(proof.TauX, bn256.Order, err)
+  data.Mu, err = NewFieldElement(proof.Mu, bn256.Order, err)
+  data.T, err = NewFieldElement(proof.T, bn256.Order, err)
+  data.L, err = NewECPointsFromCurvePoints(proof.L, err)
+  data.R, err = NewECPointsFromCurvePoints(proof.R, err)
+  data.IPA, err = NewFieldElement(proof.IPA, bn256.Order, err)
+  data.IPB, err = NewFieldElement(proof.IPB, bn256.Order, err)
+  if err != nil {
+    return nil, err
+  }
+  return data, nil
+}
+
+func RangeProofGenerate(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var rangeProofInputs RangeProofInputs
+  err := ReadContentsIntoStruct(r, &rangeProofInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  n := rangeProofInputs.N
+  if n == 0 {
+    n = MaxRangeProofBits
+  }
+  G, H, err := PedersenGenerators(rangeProofInputs.G, rangeProofInputs.H, err)
+  vs := make([]*big.Int, len(rangeProofInputs.V))
+  for j := range vs {
+    vs[j], err = NewBigInt(rangeProofInputs.V[j], err)
+  }
+  gammas := make([]*big.Int, len(rangeProofInputs.B))
+  for j := range gammas {
+    gammas[j], err = NewBigInt(rangeProofInputs.B[j], err)
+  }
+  Vs, proof, err := ProveRange(G, H, vs, gammas, n, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  commitments := make([]*CurvePoint, len(Vs))
+  for j := range Vs {
+    commitments[j] = NewCurvePoint(Vs[j])
+  }
+  encoder.Encode(Response{Commitments: commitments, RangeProof: NewRangeProof(proof)})
+}
+
+func RangeProofVerify(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var rangeProofVerifyInputs RangeProofVerifyInputs
+  err := ReadContentsIntoStruct(r, &rangeProofVerifyInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  n := rangeProofVerifyInputs.N
+  if n == 0 {
+    n = MaxRangeProofBits
+  }
+  G, H, err := PedersenGenerators(rangeProofVerifyInputs.G, rangeProofVerifyInputs.H, err)
+  Vs, err := NewECPointsFromCurvePoints(rangeProofVerifyInputs.Commitments, err)
+  proof, err := NewRangeProofData(rangeProofVerifyInputs.Proof, err)
+  isValid, err := VerifyRangeProof(G, H, Vs, n, proof, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}
+
+// the base point given by the caller, or the generator (1, 2)
+func NewBasePoint(g *CurvePoint, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  if g == nil {
+    return new(bn256.G1).ScalarBaseMult(big.NewInt(1)), nil
+  }
+  return NewECPointFromCurvePoint(g, err)
+}
+
+func DLogProofGenerate(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var dlogProofInputs DLogProofInputs
+  err := ReadContentsIntoStruct(r, &dlogProofInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  G, err := NewBasePoint(dlogProofInputs.G, err)
+  x, err := NewBigInt(dlogProofInputs.X, err)
+  P, R, s, err := ProveDLog(G, x, dlogProofInputs.Context, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{P: NewCurvePoint(P), DLogProof: &DLogProof{R: NewCurvePoint(R), S: fmt.Sprintf("0x%064x", s)}})
+}
+
+func DLogProofVerify(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var dlogProofVerifyInputs DLogProofVerifyInputs
+  err := ReadContentsIntoStruct(r, &dlogProofVerifyInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if dlogProofVerifyInputs.Proof == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing proof"}})
+    return
+  }
+  G, err := NewBasePoint(dlogProofVerifyInputs.G, err)
+  P, err := NewECPointFromCurvePoint(dlogProofVerifyInputs.P, err)
+  R, err := NewECPointFromCurvePoint(dlogProofVerifyInputs.Proof.R, err)
+  s, err := NewFieldElement(dlogProofVerifyInputs.Proof.S, bn256.Order, err)
+  isValid, err := VerifyDLog(G, P, R, s, dlogProofVerifyInputs.Context, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}
+
+func DLEQProofGenerate(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var dleqProofInputs DLEQProofInputs
+  err := ReadContentsIntoStruct(r, &dleqProofInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if dleqProofInputs.X == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing number"}})
+    return
+  }
+  G, err := NewBasePoint(dleqProofInputs.G, err)
+  H, err := NewECPointFromCurvePoint(dleqProofInputs.H, err)
+  x, err := NewBigInt(dleqProofInputs.X.V, err)
+  A, B, c, s, err := ProveDLEQ(G, H, x, dleqProofInputs.Context, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{DLEQProof: &DLEQProof{A: NewCurvePoint(A), B: NewCurvePoint(B), C: NewNumber(c), S: NewNumber(s)}})
+}
+
+func DLEQProofVerify(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var dleqProofVerifyInputs DLEQProofVerifyInputs
+  err := ReadContentsIntoStruct(r, &dleqProofVerifyInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  proof := dleqProofVerifyInputs.Proof
+  if proof == nil || proof.C == nil || proof.S == nil {
+    encoder.Encode(Response{Err: &Error{Msg: "Missing proof"}})
+    return
+  }
+  G, err := NewBasePoint(dleqProofVerifyInputs.G, err)
+  H, err := NewECPointFromCurvePoint(dleqProofVerifyInputs.H, err)
+  A, err := NewECPointFromCurvePoint(proof.A, err)
+  B, err := NewECPointFromCurvePoint(proof.B, err)
+  c, err := NewFieldElement(proof.C.V, bn256.Order, err)
+  s, err := NewFieldElement(proof.S.V, bn256.Order, err)
+  isValid, err := VerifyDLEQ(G, H, A, B, c, s, dleqProofVerifyInputs.Context, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}
+
+// kind is "opening", "equality" or "sum"
+func CommitmentProofGenerate(kind string) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var commitmentProofInputs CommitmentProofInputs
+    err := ReadContentsIntoStruct(r, &commitmentProofInputs)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    openings := commitmentProofInputs.Openings
+    if len(openings) != CommitmentProofSizes[kind] {
+      encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Number of openings must be %d", CommitmentProofSizes[kind])}})
+      return
+    }
+    G, H, err := PedersenGenerators(commitmentProofInputs.G, commitmentProofInputs.H, err)
+    if err == nil && (IsInfinity(G) || IsInfinity(H)) {
+      err = ErrBaseInfinity
+    }
+    vs := make([]*big.Int, len(openings))
+    bs := make([]*big.Int, len(openings))
+    for i, opening := range openings {
+      if opening == nil {
+        err = fmt.Errorf("Missing opening at index %d", i)
+        break
+      }
+      vs[i], err = NewBigInt(opening.V, err)
+      bs[i], err = NewBigInt(opening.B, err)
+    }
+    var Cs []*bn256.G1
+    var R *bn256.G1
+    var sv, sb *big.Int
+    if kind == "opening" {
+      var C *bn256.G1
+      C, R, sv, sb, err = ProveCommitmentOpening(G, H, vs[0], bs[0], commitmentProofInputs.Context, err)
+      Cs = []*bn256.G1{C}
+    } else {
+      Cs, R, sb, err = ProveCommitmentRelation(kind, G, H, vs, bs, commitmentProofInputs.Context, err)
+    }
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    commitments := make([]*CurvePoint, len(Cs))
+    for i := range Cs {
+      commitments[i] = NewCurvePoint(Cs[i])
+    }
+    proof := &CommitmentProof{R: NewCurvePoint(R), SB: fmt.Sprintf("0x%064x", sb)}
+    if sv != nil {
+      proof.SV = fmt.Sprintf("0x%064x", sv)
+    }
+    encoder.Encode(Response{Commitments: commitments, CommitmentProof: proof})
+  }
+}
+
+func CommitmentProofVerify(kind string) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var commitmentProofVerifyInputs CommitmentProofVerifyInputs
+    err := ReadContentsIntoStruct(r, &commitmentProofVerifyInputs)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    proof := commitmentProofVerifyInputs.Proof
+    if proof == nil {
+      encoder.Encode(Response{Err: &Error{Msg: "Missing proof"}})
+      return
+    }
+    if len(commitmentProofVerifyInputs.Commitments) != CommitmentProofSizes[kind] {
+      encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Number of commitments must be %d", CommitmentProofSizes[kind])}})
+      return
+    }
+    G, H, err := PedersenGenerators(commitmentProofVerifyInputs.G, commitmentProofVerifyInputs.H, err)
+    if err == nil && (IsInfinity(G) || IsInfinity(H)) {
+      err = ErrBaseInfinity
+    }
+    Cs, err := NewECPointsFromCurvePoints(commitmentProofVerifyInputs.Commitments, err)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    R, err := NewECPointFromCurvePoint(proof.R, err)
+    sb, err := NewFieldElement(proof.SB, bn256.Order, err)
+    var isValid bool
+    if kind == "opening" {
+      var sv *big.Int
+      sv, err = NewFieldElement(proof.SV, bn256.Order, err)
+      isValid, err = VerifyCommitmentOpening(G, H, Cs[0], R, sv, sb, commitmentProofVerifyInputs.Context, err)
+    } else {
+      isValid, err = VerifyCommitmentRelation(kind, G, H, Cs, R, sb, commitmentProofVerifyInputs.Context, err)
+    }
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+  }
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+  "bytes"
+  "crypto/rand"
+  "errors"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// Schnorr proofs of knowledge made non-interactive with a Transcript that
+// starts with the name of the proof and the caller's context, so a proof for
+// one context or statement is useless for any other
+
+var ErrBaseInfinity = errors.New("Base point must not be the point at infinity")
+
+// knowledge of x with P = x*G: R = k*G, c = H(context, G, P, R), s = k + c*x
+func ProveDLog(G *bn256.G1, x *big.Int, context string, err error) (*bn256.G1, *bn256.G1, *big.Int, error) {
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  if IsInfinity(G) {
+    return nil, nil, nil, ErrBaseInfinity
+  }
+  P := new(bn256.G1).ScalarMult(G, new(big.Int).Mod(x, bn256.Order))
+  R, s, err := ProveDLogInTranscript(DLogTranscript(G, P, context), G, x)
+  if err != nil {
+    return nil, nil, nil, err
+  }
+  return P, R, s, nil
+}
+
+func DLogTranscript(G *bn256.G1, P *bn256.G1, context string) (*Transcript) {
+  t := NewTranscript("proof/dlog")
+  t.Append("context", []byte(context))
+  t.AppendPoint("G", G)
+  t.AppendPoint("P", P)
+  return t
+}
+
+// the proof for a statement that is already in the transcript
+func ProveDLogInTranscript(t *Transcript, G *bn256.G1, x *big.Int) (*bn256.G1, *big.Int, error) {
+  k, err := rand.Int(rand.Reader, bn256.Order)
+  if err != nil {
+    return nil, nil, err
+  }
+  R := new(bn256.G1).ScalarMult(G, k)
+  t.AppendPoint("R", R)
+  c := t.Challenge("c")
+  s := new(big.Int).Mul(c, x)
+  s.Add(s, k).Mod(s, bn256.Order)
+  return R, s, nil
+}
+
+// s*G = R + c*P
+func VerifyDLogInTranscript(t *Transcript, G *bn256.G1, P *bn256.G1, R *bn256.G1, s *big.Int) (bool) {
+  t.AppendPoint("R", R)
+  c := t.Challenge("c")
+  sG := new(bn256.G1).ScalarMult(G, s)
+  expected := new(bn256.G1).Add(R, new(bn256.G1).ScalarMult(P, c))
+  return bytes.Equal(sG.Marshal(), expected.Marshal())
+}
+
+func VerifyDLog(G *bn256.G1, P *bn256.G1, R *bn256.G1, s *big.Int, context string, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  if IsInfinity(G) {
+    return false, ErrBaseInfinity
+  }
+  return VerifyDLogInTranscript(DLogTranscript(G, P, context), G, P, R, s), nil
+}
+
+// Chaum-Pedersen proof that log_G(A) = log_H(B) = x: R1 = k*G, R2 = k*H,
+// c = H(context, G, H, A, B, R1, R2) and s = k + c*x; the verifier recomputes
+// R1 = s*G - c*A and R2 = s*H - c*B and checks that they give the same c
+func ProveDLEQ(G *bn256.G1, H *bn256.G1, x *big.Int, context string, err error) (*bn256.G1, *bn256.G1, *big.Int, *big.Int, error) {
+  if err != nil {
+    return nil, nil, nil, nil, err
+  }
+  if IsInfinity(G) || IsInfinity(H) {
+    return nil, nil, nil, nil, ErrBaseInfinity
+  }
+  k, err := rand.Int(rand.Reader, bn256.Order)
+  if err != nil {
+    return nil, nil, nil, nil, err
+  }
+  x = new(big.Int).Mod(x, bn256.Order)
+  A := new(bn256.G1).ScalarMult(G, x)
+  B := new(bn256.G1).ScalarMult(H, x)
+  R1 := new(bn256.G1).ScalarMult(G, k)
+  R2 := new(bn256.G1).ScalarMult(H, k)
+  c := DLEQChallenge(G, H, A, B, R1, R2, context)
+  s := new(big.Int).Mul(c, x)
+  s.Add(s, k).Mod(s, bn256.Order)
+  return A, B, c, s, nil
+}
+
+func DLEQChallenge(G *bn256.G1, H *bn256.G1, A *bn256.G1, B *bn256.G1, R1 *bn256.G1, R2 *bn256.G1, context string) (*big.Int) {
+  t := NewTranscript("proof/dleq")
+  t.Append("context", []byte(context))
+  t.AppendPoint("G", G)
+  t.AppendPoint("H", H)
+  t.AppendPoint("A", A)
+  t.AppendPoint("B", B)
+  t.AppendPoint("R1", R1)
+  t.AppendPoint("R2", R2)
+  return t.Challenge("c")
+}
+
+func VerifyDLEQ(G *bn256.G1, H *bn256.G1, A *bn256.G1, B *bn256.G1, c *big.Int, s *big.Int, context string, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  if IsInfinity(G) || IsInfinity(H) {
+    return false, ErrBaseInfinity
+  }
+  negC := new(big.Int).Sub(bn256.Order, new(big.Int).Mod(c, bn256.Order))
+  R1 := new(bn256.G1).Add(new(bn256.G1).ScalarMult(G, s), new(bn256.G1).ScalarMult(A, negC))
+  R2 := new(bn256.G1).Add(new(bn256.G1).ScalarMult(H, s), new(bn256.G1).ScalarMult(B, negC))
+  return DLEQChallenge(G, H, A, B, R1, R2, context).Cmp(c) == 0, nil
+}
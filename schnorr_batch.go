@@ -0,0 +1,67 @@
+package main
+
+import (
+  "crypto/rand"
+  "math/big"
+)
+
+// batch verification of Schnorr signatures that come with their nonce point
+// R_i = k_i*G: once e_i is known to be the challenge for (M_i, P_i, R_i), all
+// signatures are valid iff s_i*G - e_i*P_i - R_i = 0 for every i, which is
+// checked at once with random 128-bit weights a_i as
+//   (sum_i a_i*s_i)*G - sum_i a_i*e_i*P_i - sum_i a_i*R_i = 0
+// in a single multi-scalar multiplication. Signatures without R, whose
+// challenge does not match R, or that are in a failing batch are verified one
+// by one, so the per-signature results always match
+// VerifyCurveSchnorrSignatureWithScheme
+func BatchVerifyCurveSchnorrSignatures(curve Curve, schemes []string, Ps []Point, Ms []string, Es []*big.Int, Ss []*big.Int, Rs []Point, err error) (bool, []bool, error) {
+  if err != nil {
+    return false, nil, err
+  }
+  N := curve.Params().N
+  results := make([]bool, len(Ps))
+  batch := []int{}
+  for i := range Ps {
+    var e *big.Int
+    if Rs[i] != nil {
+      e, _ = SchnorrChallengeWithScheme(curve, schemes[i], Ms[i], Ps[i], Rs[i])
+    }
+    if e != nil && e.Cmp(Es[i]) == 0 {
+      batch = append(batch, i)
+    } else {
+      results[i], _ = VerifyCurveSchnorrSignatureWithScheme(curve, schemes[i], Ps[i], Ms[i], Es[i], Ss[i], nil)
+    }
+  }
+  if len(batch) > 0 {
+    scalars := []*big.Int{new(big.Int)}
+    points := []Point{curve.BaseMul(big.NewInt(1))}
+    bound := new(big.Int).Lsh(big.NewInt(1), 128)
+    for _, i := range batch {
+      a, err := rand.Int(rand.Reader, bound)
+      if err != nil {
+        return false, nil, err
+      }
+      scalars[0].Add(scalars[0], new(big.Int).Mul(a, Ss[i]))
+      aE := new(big.Int).Mul(a, Es[i])
+      scalars = append(scalars, aE.Sub(N, aE.Mod(aE, N)), new(big.Int).Sub(N, a))
+      points = append(points, Ps[i], Rs[i])
+    }
+    sum, err := CurveMultiScalarMult(curve, scalars, points, err)
+    if err != nil {
+      return false, nil, err
+    }
+    batchValid := sum.IsInfinity()
+    for _, i := range batch {
+      if batchValid {
+        results[i] = true
+      } else {
+        results[i], _ = VerifyCurveSchnorrSignatureWithScheme(curve, schemes[i], Ps[i], Ms[i], Es[i], Ss[i], nil)
+      }
+    }
+  }
+  allValid := true
+  for _, valid := range results {
+    allValid = allValid && valid
+  }
+  return allValid, results, nil
+}
@@ -1,36 +1,148 @@
 package main
 
 import (
+  "bytes"
   "fmt"
   "log"
   "net/http"
+  "net/http/httptest"
   "encoding/json"
+  "math/big"
   "github.com/gorilla/mux"
+  "github.com/rynobey/bn256"
 )
 
 var port = "8083"
 
 func main() {
+  router := NewRouter()
+  fmt.Printf("Listening on port %s\n", port)
+  log.Fatal(http.ListenAndServe(":"+port, router))
+}
+
+func NewRouter() (*mux.Router) {
   router := mux.NewRouter().StrictSlash(true)
   router.HandleFunc("/isalive", IsAlive).Methods("GET")
   router.HandleFunc("/generate/keccak256/", GenerateKeccak256).Methods("POST")
   router.HandleFunc("/generate/commitment/", GenerateCommitment).Methods("POST")
+  router.HandleFunc("/generate/commitment/vector/", GenerateVectorCommitment).Methods("POST")
+  router.HandleFunc("/verify/commitment/", VerifyCommitment).Methods("POST")
+  router.HandleFunc("/commitment/add/", CommitmentAdd).Methods("POST")
+  router.HandleFunc("/commitment/sub/", CommitmentSub).Methods("POST")
+  router.HandleFunc("/commitment/scale/", CommitmentScale).Methods("POST")
+  router.HandleFunc("/commitment/balance/", CommitmentBalance).Methods("POST")
+  router.HandleFunc("/proof/range/generate/", RangeProofGenerate).Methods("POST")
+  router.HandleFunc("/proof/range/verify/", RangeProofVerify).Methods("POST")
+  router.HandleFunc("/proof/dlog/generate/", DLogProofGenerate).Methods("POST")
+  router.HandleFunc("/proof/dlog/verify/", DLogProofVerify).Methods("POST")
+  router.HandleFunc("/proof/dleq/generate/", DLEQProofGenerate).Methods("POST")
+  router.HandleFunc("/proof/dleq/verify/", DLEQProofVerify).Methods("POST")
+  for _, kind := range []string{"opening", "equality", "sum"} {
+    router.HandleFunc("/proof/commitment/" + kind + "/generate/", CommitmentProofGenerate(kind)).Methods("POST")
+    router.HandleFunc("/proof/commitment/" + kind + "/verify/", CommitmentProofVerify(kind)).Methods("POST")
+  }
   router.HandleFunc("/generate/schnorr/", GenerateSchnorr).Methods("POST")
   router.HandleFunc("/verify/schnorr/", VerifySchnorr).Methods("POST")
+  router.HandleFunc("/verify/schnorr/batch/", VerifySchnorrBatch).Methods("POST")
+  router.HandleFunc("/frost/commit/", FrostCommit).Methods("POST")
+  router.HandleFunc("/frost/sign/", FrostSign).Methods("POST")
+  router.HandleFunc("/frost/aggregate/", FrostAggregateSignature).Methods("POST")
+  router.HandleFunc("/musig2/keyagg/", MuSig2KeyAgg).Methods("POST")
+  router.HandleFunc("/musig2/nonce", MuSig2GenerateNonce).Methods("GET")
+  router.HandleFunc("/musig2/sign/", MuSig2Sign).Methods("POST")
+  router.HandleFunc("/musig2/aggregate/", MuSig2AggregateSignature).Methods("POST")
+  router.HandleFunc("/generate/bls/keypair", GenerateBLSKeyPair).Methods("GET")
+  router.HandleFunc("/generate/bls/", GenerateBLS).Methods("POST")
+  router.HandleFunc("/generate/bls/pop/", GenerateBLSPoP).Methods("POST")
+  router.HandleFunc("/generate/bls/aggregate/", AggregateBLS).Methods("POST")
+  router.HandleFunc("/verify/bls/", VerifyBLS).Methods("POST")
+  router.HandleFunc("/verify/bls/pop/", VerifyBLSPoP).Methods("POST")
+  router.HandleFunc("/verify/bls/aggregate/", VerifyAggregateBLS).Methods("POST")
+  router.HandleFunc("/pairing/", Pairing).Methods("POST")
+  router.HandleFunc("/pairing/check/", PairingCheck).Methods("POST")
   router.HandleFunc("/big/add/", BigIntAdd).Methods("POST")
   router.HandleFunc("/big/submod/", BigIntSubMod).Methods("POST")
   router.HandleFunc("/big/invmod/", BigIntInvMod).Methods("POST")
   router.HandleFunc("/big/mul/", BigIntMul).Methods("POST")
   router.HandleFunc("/big/mod/", BigIntMod).Methods("POST")
+  router.HandleFunc("/big/sub/", BigIntSub).Methods("POST")
+  router.HandleFunc("/big/modexp/", BigIntModExp).Methods("POST")
+  router.HandleFunc("/big/divmod/", BigIntDivMod).Methods("POST")
+  router.HandleFunc("/big/gcd/", BigIntGCD).Methods("POST")
+  router.HandleFunc("/big/egcd/", BigIntEGCD).Methods("POST")
+  router.HandleFunc("/big/cmp/", BigIntCmp).Methods("POST")
+  router.HandleFunc("/big/sqrtmod/", BigIntSqrtMod).Methods("POST")
+  router.HandleFunc("/big/legendre/", BigIntLegendre).Methods("POST")
+  router.HandleFunc("/big/jacobi/", BigIntJacobi).Methods("POST")
+  router.HandleFunc("/big/isprime/", BigIntIsPrime).Methods("POST")
   router.HandleFunc("/big/rand", CryptoRandBigInt).Methods("GET")
+  for prefix, modulus := range map[string]*big.Int{"/fr": bn256.Order, "/fp": bn256.P} {
+    router.HandleFunc(prefix + "/add/", FieldAdd(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/sub/", FieldSub(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/mul/", FieldMul(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/inv/", FieldInv(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/neg/", FieldNeg(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/pow/", FieldPow(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/sqrt/", FieldSqrt(modulus)).Methods("POST")
+    router.HandleFunc(prefix + "/batchinv/", FieldBatchInv(modulus)).Methods("POST")
+  }
+  router.HandleFunc("/sss/split/", SSSSplit).Methods("POST")
+  router.HandleFunc("/sss/combine/", SSSCombine).Methods("POST")
+  router.HandleFunc("/vss/feldman/split/", VSSSplit(false)).Methods("POST")
+  router.HandleFunc("/vss/pedersen/split/", VSSSplit(true)).Methods("POST")
+  router.HandleFunc("/vss/verify/", VSSVerify).Methods("POST")
+  router.HandleFunc("/poly/eval/", PolyEvaluate).Methods("POST")
+  router.HandleFunc("/poly/mul/", PolyMultiply).Methods("POST")
+  router.HandleFunc("/poly/divide/", PolyDivide).Methods("POST")
+  router.HandleFunc("/poly/interpolate/", PolyInterpolation).Methods("POST")
+  router.HandleFunc("/poly/ntt/", PolyNTT(false)).Methods("POST")
+  router.HandleFunc("/poly/intt/", PolyNTT(true)).Methods("POST")
   router.HandleFunc("/ec/order", ECOrder)
   router.HandleFunc("/ec/add/", ECAdd).Methods("POST")
   router.HandleFunc("/ec/sub/", ECSub).Methods("POST")
   router.HandleFunc("/ec/mul/", ECMul).Methods("POST")
   router.HandleFunc("/ec/basemul/", ECBaseMul).Methods("POST")
+  router.HandleFunc("/ec/msm/", ECMSM).Methods("POST")
+  router.HandleFunc("/ec/neg/", ECNeg).Methods("POST")
+  router.HandleFunc("/ec/eq/", ECEq).Methods("POST")
+  router.HandleFunc("/ec/isinfinity/", ECIsInfinity).Methods("POST")
+  router.HandleFunc("/ec/validate/", ECValidate).Methods("POST")
   router.HandleFunc("/ec/hashtopoint/", ECHashToPoint).Methods("POST")
-  fmt.Printf("Listening on port %s\n", port)
-  log.Fatal(http.ListenAndServe(":"+port, router))
+  router.HandleFunc("/ec/g2/add/", ECG2Add).Methods("POST")
+  router.HandleFunc("/ec/g2/sub/", ECG2Sub).Methods("POST")
+  router.HandleFunc("/ec/g2/mul/", ECG2Mul).Methods("POST")
+  router.HandleFunc("/ec/g2/basemul/", ECG2BaseMul).Methods("POST")
+  router.HandleFunc("/ec/g2/validate/", ECG2Validate).Methods("POST")
+  router.HandleFunc("/batch/", NewBatchHandler(router)).Methods("POST")
+  router.Use(CompressedPointsMiddleware)
+  return router
+}
+
+// clients ask for compressed curve points with ?encoding=compressed or the
+// X-Point-Encoding: compressed header
+func CompressedPointsMiddleware(next http.Handler) (http.Handler) {
+  return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+    if r.URL.Query().Get("encoding") != "compressed" && r.Header.Get("X-Point-Encoding") != "compressed" {
+      next.ServeHTTP(w, r)
+      return
+    }
+    rec := httptest.NewRecorder()
+    next.ServeHTTP(rec, r)
+    for key, values := range rec.Header() {
+      w.Header()[key] = values
+    }
+    contents := rec.Body.Bytes()
+    var res interface{}
+    decoder := json.NewDecoder(bytes.NewReader(contents))
+    decoder.UseNumber()
+    if rec.Code != http.StatusOK || decoder.Decode(&res) != nil {
+      w.WriteHeader(rec.Code)
+      w.Write(contents)
+      return
+    }
+    encoder := json.NewEncoder(w)
+    encoder.Encode(CompressCurvePoints(res))
+  })
 }
 
 func IsAlive(w http.ResponseWriter, r *http.Request) {
@@ -2,7 +2,9 @@ package main
 
 import (
   "testing"
+  "crypto/sha256"
   "crypto/rand"
+  "crypto/elliptic"
   "net/http"
   "io/ioutil"
   "encoding/json"
@@ -651,3 +653,3876 @@ func TestIsAlive(t *testing.T) {
     t.Errorf("Invalid value returned\n")
   }
 }
+
+func TestECG2Add(t *testing.T) {
+  A := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(10))
+  B := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(100))
+  binaryG2OpParams := BinaryG2OpParams{A: NewG2Point(A), B: NewG2Point(B)}
+  marshalledJSON, _ := json.Marshal(binaryG2OpParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/g2/add/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  pt := res.P2
+  P := new(bn256.G2)
+  marshalledPoint := pt.X.Im[2:] + pt.X.Re[2:] + pt.Y.Im[2:] + pt.Y.Re[2:]
+  marshalledBytes, err := hex.DecodeString(marshalledPoint)
+  if err != nil {
+    t.Errorf("An error occurred while decoding hex string: %s\n", err)
+    return
+  }
+  _, err = P.Unmarshal(marshalledBytes)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 G2 point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G2).Add(A, B)
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECG2Sub(t *testing.T) {
+  A := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(10))
+  B := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(100))
+  binaryG2OpParams := BinaryG2OpParams{A: NewG2Point(A), B: NewG2Point(B)}
+  marshalledJSON, _ := json.Marshal(binaryG2OpParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/g2/sub/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  pt := res.P2
+  P := new(bn256.G2)
+  marshalledPoint := pt.X.Im[2:] + pt.X.Re[2:] + pt.Y.Im[2:] + pt.Y.Re[2:]
+  marshalledBytes, err := hex.DecodeString(marshalledPoint)
+  if err != nil {
+    t.Errorf("An error occurred while decoding hex string: %s\n", err)
+    return
+  }
+  _, err = P.Unmarshal(marshalledBytes)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 G2 point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G2).Add(A, new(bn256.G2).Neg(B))
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECG2Mul(t *testing.T) {
+  s := new(big.Int).SetInt64(100)
+  A := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(10))
+  scalarG2OpParams := ScalarG2OpParams{S: NewNumber(s), A: NewG2Point(A)}
+  marshalledJSON, _ := json.Marshal(scalarG2OpParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/g2/mul/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  pt := res.P2
+  P := new(bn256.G2)
+  marshalledPoint := pt.X.Im[2:] + pt.X.Re[2:] + pt.Y.Im[2:] + pt.Y.Re[2:]
+  marshalledBytes, err := hex.DecodeString(marshalledPoint)
+  if err != nil {
+    t.Errorf("An error occurred while decoding hex string: %s\n", err)
+    return
+  }
+  _, err = P.Unmarshal(marshalledBytes)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 G2 point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G2).ScalarMult(A, s)
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECG2BaseMul(t *testing.T) {
+  s := new(big.Int).SetInt64(100)
+  s_val := NewNumber(s)
+  marshalledJSON, _ := json.Marshal(s_val)
+  response, err := http.Post("http://localhost:" + port + "/ec/g2/basemul/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  pt := res.P2
+  P := new(bn256.G2)
+  marshalledPoint := pt.X.Im[2:] + pt.X.Re[2:] + pt.Y.Im[2:] + pt.Y.Re[2:]
+  marshalledBytes, err := hex.DecodeString(marshalledPoint)
+  if err != nil {
+    t.Errorf("An error occurred while decoding hex string: %s\n", err)
+    return
+  }
+  _, err = P.Unmarshal(marshalledBytes)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 G2 point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G2).ScalarBaseMult(s)
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECG2Validate(t *testing.T) {
+  A := NewG2Point(new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(10)))
+  A.Y.Re = A.X.Re
+  marshalledJSON, _ := json.Marshal(A)
+  response, err := http.Post("http://localhost:" + port + "/ec/g2/validate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "false") {
+    t.Errorf("Invalid G2 point accepted\n")
+  }
+}
+
+func TestECPointLongCoordinate(t *testing.T) {
+  // a 65th hex digit used to be shifted into the next coordinate
+  A := NewCurvePoint(new(bn256.G1).ScalarBaseMult(big.NewInt(10)))
+  _, err := NewECPoint("0x0" + A.X[2:], A.Y, nil)
+  if (err != ErrCoordinateTooLong) {
+    t.Errorf("Long G1 coordinate accepted")
+    return
+  }
+  B := NewG2Point(new(bn256.G2).ScalarBaseMult(big.NewInt(10)))
+  B.X.Re = "0x0" + B.X.Re[2:]
+  _, err = NewECPointG2(B, nil)
+  if (err != ErrCoordinateTooLong) {
+    t.Errorf("Long G2 coordinate accepted")
+    return
+  }
+}
+
+func TestPairing(t *testing.T) {
+  A := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(10))
+  B := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(100))
+  pairingInputs := PairingInputs{A: NewCurvePoint(A), B: NewG2Point(B)}
+  marshalledJSON, _ := json.Marshal(pairingInputs)
+  response, err := http.Post("http://localhost:" + port + "/pairing/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  gtTest := NewGTElement(bn256.Pair(A, B))
+  if (fmt.Sprintf("%v", res.GT.C) != fmt.Sprintf("%v", gtTest.C)) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestPairingCheck(t *testing.T) {
+  A := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(10))
+  B := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(100))
+  C := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(1000))
+  D := new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(1))
+  input := append(A.Marshal(), B.Marshal()...)
+  input = append(input, C.Neg(C).Marshal()...)
+  input = append(input, D.Marshal()...)
+  pairingCheckInputs := PairingCheckInputs{Input: fmt.Sprintf("0x%x", input)}
+  marshalledJSON, _ := json.Marshal(pairingCheckInputs)
+  response, err := http.Post("http://localhost:" + port + "/pairing/check/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestGenerateBLSKeyPair(t *testing.T) {
+  response, err := http.Get("http://localhost:" + port + "/generate/bls/keypair")
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  x, ok := new(big.Int).SetString(res.Num.V[2:], 16)
+  if !ok {
+    t.Errorf("An error occurred while initializing big.Int from string")
+    return
+  }
+  P, err := NewECPointG2(res.P2, nil)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 G2 point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G2).ScalarBaseMult(x)
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Public key does not match private key\n")
+  }
+}
+
+func TestGenerateBLS(t *testing.T) {
+  x, _ := rand.Int(rand.Reader, bn256.Order)
+  m := "This is the message to be signed"
+  generateBLSInputs := GenerateBLSInputs{Priv: fmt.Sprintf("0x%064x", x), M: m}
+  marshalledJSON, _ := json.Marshal(generateBLSInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/bls/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  sig := res.BLSSig
+  P, err := NewECPointG2(sig.P, nil)
+  S, err := NewECPoint(sig.S.X, sig.S.Y, err)
+  isValid, err := VerifyBLSSignature(P, sig.M, S, err)
+  if err != nil {
+    t.Errorf("An error occurred while verifying BLS signature: %s\n", err)
+    return
+  }
+  if (!isValid) {
+    t.Errorf("Invalid BLS signature generated")
+  }
+}
+
+func TestVerifyBLS(t *testing.T) {
+  x, _ := rand.Int(rand.Reader, bn256.Order)
+  m := "This is the message to be signed"
+  P, S, err := GenerateBLSSignature(m, x, nil)
+  blsSignature := BLSSignature{P: NewG2Point(P), M: m, S: NewCurvePoint(S)}
+  marshalledJSON, _ := json.Marshal(blsSignature)
+  response, err := http.Post("http://localhost:" + port + "/verify/bls/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Valid BLS signature rejected")
+  }
+}
+
+func TestVerifyBLSInfinity(t *testing.T) {
+  // with the key and the signature at infinity both sides of the pairing
+  // check are 1 for any message
+  P := new(bn256.G2).ScalarBaseMult(new(big.Int))
+  S := new(bn256.G1).ScalarBaseMult(new(big.Int))
+  blsSignature := BLSSignature{P: NewG2Point(P), M: "any message", S: NewCurvePoint(S)}
+  marshalledJSON, _ := json.Marshal(blsSignature)
+  response, err := http.Post("http://localhost:" + port + "/verify/bls/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if (res.Err == nil || res.Err.Msg != ErrSignatureInfinity.Error()) {
+    t.Errorf("Signature at infinity not rejected")
+    return
+  }
+  _, err = VerifyBLSPossessionProof(P, S, nil)
+  if (err != ErrKeyInfinity) {
+    t.Errorf("Proof of possession for the key at infinity not rejected")
+    return
+  }
+}
+
+func TestAggregateBLS(t *testing.T) {
+  x1, _ := rand.Int(rand.Reader, bn256.Order)
+  x2, _ := rand.Int(rand.Reader, bn256.Order)
+  P1, S1, err := GenerateBLSSignature("First message", x1, nil)
+  P2, S2, err := GenerateBLSSignature("Second message", x2, err)
+  _, pop1, err := GenerateBLSPossessionProof(x1, err)
+  _, pop2, err := GenerateBLSPossessionProof(x2, err)
+  aggregateBLSInputs := AggregateBLSInputs{Sigs: []*CurvePoint{NewCurvePoint(S1), NewCurvePoint(S2)}, Keys: []*G2Point{NewG2Point(P1), NewG2Point(P2)}, PoPs: []*CurvePoint{NewCurvePoint(pop1), NewCurvePoint(pop2)}}
+  marshalledJSON, _ := json.Marshal(aggregateBLSInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/bls/aggregate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  Stest := new(bn256.G1).Add(S1, S2)
+  Ptest := new(bn256.G2).Add(P1, P2)
+  if (res.P.X != NewCurvePoint(Stest).X || res.P2.X.Re != NewG2Point(Ptest).X.Re) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestAggregateBLSRogueKey(t *testing.T) {
+  // nobody knows the private key of p' = x'*g2 - p1, so it has no valid
+  // proof of possession
+  x1, _ := rand.Int(rand.Reader, bn256.Order)
+  x2, _ := rand.Int(rand.Reader, bn256.Order)
+  P1, pop1, _ := GenerateBLSPossessionProof(x1, nil)
+  rogue := new(bn256.G2).Add(new(bn256.G2).ScalarBaseMult(x2), new(bn256.G2).Neg(P1))
+  _, pop2, _ := GenerateBLSPossessionProof(x2, nil)
+  cases := []AggregateBLSInputs{
+    AggregateBLSInputs{Keys: []*G2Point{NewG2Point(P1), NewG2Point(rogue)}, PoPs: []*CurvePoint{NewCurvePoint(pop1)}},
+    AggregateBLSInputs{Keys: []*G2Point{NewG2Point(P1), NewG2Point(rogue)}, PoPs: []*CurvePoint{NewCurvePoint(pop1), NewCurvePoint(pop2)}},
+  }
+  for i, aggregateBLSInputs := range cases {
+    marshalledJSON, _ := json.Marshal(aggregateBLSInputs)
+    response, err := http.Post("http://localhost:" + port + "/generate/bls/aggregate/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if (res.Err == nil || res.Err.Msg == "") {
+      t.Errorf("Key without a valid proof of possession aggregated in case %d\n", i)
+      return
+    }
+  }
+}
+
+func TestBLSPoP(t *testing.T) {
+  x, _ := rand.Int(rand.Reader, bn256.Order)
+  generateBLSPoPInputs := GenerateBLSPoPInputs{Priv: fmt.Sprintf("0x%064x", x)}
+  marshalledJSON, _ := json.Marshal(generateBLSPoPInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/bls/pop/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  otherKey := NewG2Point(new(bn256.G2).ScalarBaseMult(new(big.Int).Add(x, big.NewInt(1))))
+  cases := []BLSPoP{*res.BLSPoP, BLSPoP{P: otherKey, S: res.BLSPoP.S}}
+  expected := []string{"true", "false"}
+  for i, blsPoP := range cases {
+    marshalledJSON, _ := json.Marshal(blsPoP)
+    response, err := http.Post("http://localhost:" + port + "/verify/bls/pop/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var verifyRes Response
+    err = json.Unmarshal(contents, &verifyRes)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if verifyRes.Err != nil && verifyRes.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", verifyRes.Err.Msg))
+      return
+    }
+    if (verifyRes.Text != expected[i]) {
+      t.Errorf("Wrong result for case %d", i)
+      return
+    }
+  }
+}
+
+func TestVerifyAggregateBLS(t *testing.T) {
+  x1, _ := rand.Int(rand.Reader, bn256.Order)
+  x2, _ := rand.Int(rand.Reader, bn256.Order)
+  P1, S1, err := GenerateBLSSignature("First message", x1, nil)
+  P2, S2, err := GenerateBLSSignature("Second message", x2, err)
+  S := new(bn256.G1).Add(S1, S2)
+  aggregateBLSSignature := AggregateBLSSignature{P: []*G2Point{NewG2Point(P1), NewG2Point(P2)}, M: []string{"First message", "Second message"}, S: NewCurvePoint(S)}
+  marshalledJSON, _ := json.Marshal(aggregateBLSSignature)
+  response, err := http.Post("http://localhost:" + port + "/verify/bls/aggregate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Valid aggregate BLS signature rejected")
+  }
+}
+
+func TestECMSM(t *testing.T) {
+  n := 50
+  scalars := make([]*Number, n)
+  points := make([]*CurvePoint, n)
+  Ptest := new(bn256.G1).ScalarBaseMult(new(big.Int))
+  for i := 0; i < n; i++ {
+    s, _ := rand.Int(rand.Reader, bn256.Order)
+    A := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(int64(i+1)))
+    scalars[i] = NewNumber(s)
+    points[i] = NewCurvePoint(A)
+    Ptest = new(bn256.G1).Add(Ptest, new(bn256.G1).ScalarMult(A, s))
+  }
+  msmParams := MSMParams{S: scalars, A: points}
+  marshalledJSON, _ := json.Marshal(msmParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/msm/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  P, err := NewECPoint(res.P.X, res.P.Y, nil)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 curve point: %s\n", err)
+    return
+  }
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestBatch(t *testing.T) {
+  batchItems := []*BatchItem{
+    &BatchItem{Op: "/ec/basemul/", Params: json.RawMessage(`{"v":"0x0a"}`)},
+    &BatchItem{Op: "/ec/add/", Params: json.RawMessage(`{"a":"$0.curvepoint","b":"$0.curvepoint"}`)},
+    &BatchItem{Op: "/ec/mul/", Params: json.RawMessage(`{"s":{"v":"0x05"},"a":"$1.curvepoint"}`)},
+    &BatchItem{Op: "/ec/order"},
+    &BatchItem{Op: "/ec/mul/", Params: json.RawMessage(`{"s":{"v":"0x05"},"a":"$5.curvepoint"}`)},
+  }
+  marshalledJSON, _ := json.Marshal(batchItems)
+  response, err := http.Post("http://localhost:" + port + "/batch/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Results) != 5) {
+    t.Errorf("Expected one result per item\n")
+    return
+  }
+  var mulRes Response
+  err = json.Unmarshal(res.Results[2], &mulRes)
+  if err != nil || mulRes.P == nil {
+    t.Errorf("Chained result missing: %s\n", res.Results[2])
+    return
+  }
+  P, err := NewECPoint(mulRes.P.X, mulRes.P.Y, nil)
+  Ptest := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(100))
+  if (err != nil || P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+  var orderRes Response
+  err = json.Unmarshal(res.Results[3], &orderRes)
+  if err != nil || orderRes.Num == nil || orderRes.Num.V != NewNumber(bn256.Order).V {
+    t.Errorf("Incorrect answer returned for GET item\n")
+  }
+  var badRefRes Response
+  err = json.Unmarshal(res.Results[4], &badRefRes)
+  if err != nil || badRefRes.Err == nil {
+    t.Errorf("Expected an error for a reference to a later item\n")
+  }
+}
+
+func TestBatchMalformedOps(t *testing.T) {
+  batchItems := []*BatchItem{
+    &BatchItem{Op: "ec/add/", Params: json.RawMessage(`{"a":{"x":"0x01","y":"0x02"},"b":{"x":"0x01","y":"0x02"}}`)},
+    &BatchItem{Op: "/ec/%zz/"},
+    &BatchItem{Op: "/ec/order", Method: "GE T"},
+    &BatchItem{Op: "/ec/order"},
+  }
+  marshalledJSON, _ := json.Marshal(batchItems)
+  response, err := http.Post("http://localhost:" + port + "/batch/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Results) != 4) {
+    t.Errorf("Expected one result per item\n")
+    return
+  }
+  for i := 0; i < 3; i++ {
+    var itemRes Response
+    json.Unmarshal(res.Results[i], &itemRes)
+    if (itemRes.Err == nil || itemRes.Err.Msg == "") {
+      t.Errorf("Malformed op %d not detected\n", i)
+      return
+    }
+  }
+  var orderRes Response
+  json.Unmarshal(res.Results[3], &orderRes)
+  if (orderRes.Err != nil || orderRes.Num == nil) {
+    t.Errorf("Valid op after malformed ones failed: %s\n", res.Results[3])
+    return
+  }
+}
+
+func TestBatchTooLarge(t *testing.T) {
+  batchItems := make([]*BatchItem, MaxBatchItems + 1)
+  for i := range batchItems {
+    batchItems[i] = &BatchItem{Op: "/ec/order"}
+  }
+  marshalledJSON, _ := json.Marshal(batchItems)
+  response, err := http.Post("http://localhost:" + port + "/batch/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if (res.Err == nil || res.Err.Msg == "") {
+    t.Errorf("Oversized batch not rejected\n")
+    return
+  }
+}
+
+func TestECNeg(t *testing.T) {
+  A := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(10))
+  marshalledJSON, _ := json.Marshal(NewCurvePoint(A))
+  response, err := http.Post("http://localhost:" + port + "/ec/neg/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  P, err := NewECPoint(res.P.X, res.P.Y, nil)
+  if err != nil {
+    t.Errorf("An error occurred while unmarshalling BN256 curve point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G1).Neg(A)
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECEq(t *testing.T) {
+  A := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(10))
+  B := new(bn256.G1).Add(new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(4)), new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(6)))
+  binaryEcOpParams := BinaryEcOpParams{A: NewCurvePoint(A), B: NewCurvePoint(B)}
+  marshalledJSON, _ := json.Marshal(binaryEcOpParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/eq/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Equal points reported as different\n")
+  }
+}
+
+func TestECIsInfinity(t *testing.T) {
+  A := new(bn256.G1).ScalarBaseMult(bn256.Order)
+  marshalledJSON, _ := json.Marshal(NewCurvePoint(A))
+  response, err := http.Post("http://localhost:" + port + "/ec/isinfinity/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Point at infinity not detected\n")
+  }
+}
+
+func TestECValidate(t *testing.T) {
+  curvePoint := CurvePoint{X: "0x01", Y: "0x03"}
+  marshalledJSON, _ := json.Marshal(curvePoint)
+  response, err := http.Post("http://localhost:" + port + "/ec/validate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Verdict == nil || res.Verdict.Valid || res.Verdict.Reason != "not_on_curve") {
+    t.Errorf("Point off the curve not detected\n")
+  }
+}
+
+func TestECAddCompressed(t *testing.T) {
+  A := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(10))
+  B := new(bn256.G1).ScalarBaseMult(new(big.Int).SetInt64(100))
+  a_pt := NewCurvePoint(A)
+  b_pt := NewCurvePoint(B)
+  a_c, err := CompressCurvePoint(a_pt.X, a_pt.Y, nil)
+  b_c, err := CompressCurvePoint(b_pt.X, b_pt.Y, err)
+  if err != nil {
+    t.Errorf("An error occurred while compressing curve points: %s\n", err)
+    return
+  }
+  binaryEcOpParams := BinaryEcOpParams{A: &CurvePoint{C: a_c}, B: &CurvePoint{C: b_c}}
+  marshalledJSON, _ := json.Marshal(binaryEcOpParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/add/?encoding=compressed", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.P.C == "" || res.P.X != "" || len(res.P.C) != 68) {
+    t.Errorf("Expected a compressed curve point, got %v\n", res.P)
+    return
+  }
+  P, err := NewECPointFromCurvePoint(res.P, nil)
+  if err != nil {
+    t.Errorf("An error occurred while decompressing curve point: %s\n", err)
+    return
+  }
+  Ptest := new(bn256.G1).Add(A, B)
+  if (P.String() != Ptest.String()) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECHashToPointRFC9380(t *testing.T) {
+  hashToPointInputs := HashToPointInputs{T: "", Mode: "rfc9380", DST: "QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_"}
+  marshalledJSON, _ := json.Marshal(hashToPointInputs)
+  response, err := http.Post("http://localhost:" + port + "/ec/hashtopoint/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  pt := res.P
+  if (pt.X != "0x0a976ab906170db1f9638d376514dbf8c42aef256a54bbd48521f20749e59e86" || pt.Y != "0x02925ead66b9e68bfc309b014398640ab55f6619ab59bc1fab2210ad4c4d53d5") {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECHashToPointCounter(t *testing.T) {
+  str := "input to hash function"
+  hashToPointInputs := HashToPointInputs{T: str, Mode: "legacy"}
+  marshalledJSON, _ := json.Marshal(hashToPointInputs)
+  response, err := http.Post("http://localhost:" + port + "/ec/hashtopoint/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Counter == nil) {
+    t.Errorf("Missing counter\n")
+    return
+  }
+  digest := sha256.Sum256(append([]byte(str), byte(*res.Counter)))
+  x := new(big.Int).SetBytes(digest[:])
+  x.Mod(x, bn256.P)
+  if (res.P.X != fmt.Sprintf("0x%064x", x)) {
+    t.Errorf("Counter does not reproduce the returned point\n")
+  }
+}
+
+func TestECBaseMulSecp256k1(t *testing.T) {
+  number := Number{V: "0x2"}
+  marshalledJSON, _ := json.Marshal(number)
+  response, err := http.Post("http://localhost:" + port + "/ec/basemul/?curve=secp256k1", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  pt := res.P
+  if (pt.X != "0xc6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5" || pt.Y != "0x1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a") {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestECAddP256(t *testing.T) {
+  curve := elliptic.P256()
+  x1, y1 := curve.ScalarBaseMult(big.NewInt(12345).Bytes())
+  x2, y2 := curve.ScalarBaseMult(big.NewInt(67890).Bytes())
+  binaryEcOpParams := BinaryEcOpParams{
+    A: &CurvePoint{X: fmt.Sprintf("0x%064x", x1), Y: fmt.Sprintf("0x%064x", y1)},
+    B: &CurvePoint{X: fmt.Sprintf("0x%064x", x2), Y: fmt.Sprintf("0x%064x", y2)},
+  }
+  marshalledJSON, _ := json.Marshal(binaryEcOpParams)
+  response, err := http.Post("http://localhost:" + port + "/ec/add/?curve=p256", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  x, y := curve.Add(x1, y1, x2, y2)
+  pt := res.P
+  if (pt.X != fmt.Sprintf("0x%064x", x) || pt.Y != fmt.Sprintf("0x%064x", y)) {
+    t.Errorf("Incorrect answer returned\n")
+  }
+}
+
+func TestSchnorrP256(t *testing.T) {
+  P := elliptic.P256()
+  x, y := P.ScalarBaseMult(big.NewInt(0x1234).Bytes())
+  generateSchnorrInputs := GenerateSchnorrInputs{Priv: "0x1234", M: "message to sign"}
+  marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/schnorr/?curve=p256", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  sig := res.Sig
+  if (sig.P.X != fmt.Sprintf("0x%064x", x) || sig.P.Y != fmt.Sprintf("0x%064x", y)) {
+    t.Errorf("Incorrect public key returned\n")
+    return
+  }
+  pk, err := P256.NewPoint(sig.P, nil)
+  E, err := NewBigInt(sig.E, err)
+  S, err := NewBigInt(sig.S, err)
+  isValid, err := VerifyCurveSchnorrSignature(P256, pk, sig.M, E, S, err)
+  if (err != nil || !isValid) {
+    t.Errorf("Signature does not verify on p256\n")
+  }
+}
+
+func TestECUnknownCurve(t *testing.T) {
+  response, err := http.Get("http://localhost:" + port + "/ec/order?curve=ed25519")
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err == nil || res.Err.Msg != "Unknown curve: ed25519" {
+    t.Errorf("Expected an unknown curve error\n")
+  }
+}
+
+func TestBigSub(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "0x5", B: "0x9"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/sub/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Num.V != "-0x4") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigModExp(t *testing.T) {
+  ternaryOpParams := TernaryOpParams{A: "0x3", B: "0x10", C: "0x65"}
+  marshalledJSON, _ := json.Marshal(ternaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/modexp/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := new(big.Int).Exp(big.NewInt(3), big.NewInt(16), big.NewInt(101))
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigDivMod(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "-0x7", B: "0x2"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/divmod/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Nums) != 2 || res.Nums[0].V != "-0x4" || res.Nums[1].V != "0x1") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigGCD(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "0xf0", B: "0x2e"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/gcd/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Num.V != "0x2") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigEGCD(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "0xf0", B: "0x2e"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/egcd/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Nums) != 3) {
+    t.Errorf("Wrong number of values returned")
+    return
+  }
+  g, err := NewBigInt(res.Nums[0].V, nil)
+  x, err := NewBigInt(res.Nums[1].V, err)
+  y, err := NewBigInt(res.Nums[2].V, err)
+  if err != nil {
+    t.Errorf("An error occurred while reading numbers: %s\n", err)
+    return
+  }
+  lhs := new(big.Int).Add(new(big.Int).Mul(big.NewInt(0xf0), x), new(big.Int).Mul(big.NewInt(0x2e), y))
+  if (g.Cmp(big.NewInt(2)) != 0 || lhs.Cmp(g) != 0) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigCmp(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "-0x1", B: "0x0"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/cmp/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "-1") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigSqrtMod(t *testing.T) {
+  p := bn256.P
+  a := big.NewInt(4)
+  binaryOpParams := BinaryOpParams{A: fmt.Sprintf("0x%x", a), B: fmt.Sprintf("0x%x", p)}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/sqrtmod/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  root, err := NewBigInt(res.Num.V, nil)
+  if err != nil || new(big.Int).Exp(root, big.NewInt(2), p).Cmp(a) != 0 {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigSqrtModTwo(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "0x1", B: "0x2"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/sqrtmod/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if (res.Err == nil || res.Err.Msg != "Modulus must be an odd prime") {
+    t.Errorf("Even modulus not rejected\n")
+    return
+  }
+}
+
+func TestBigLegendre(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "0x3", B: "0x7"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/legendre/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "-1") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigJacobi(t *testing.T) {
+  binaryOpParams := BinaryOpParams{A: "0x2", B: "0xf"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/big/jacobi/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "1") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestBigIsPrime(t *testing.T) {
+  number := Number{V: fmt.Sprintf("0x%x", bn256.Order)}
+  marshalledJSON, _ := json.Marshal(number)
+  response, err := http.Post("http://localhost:" + port + "/big/isprime/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrAdd(t *testing.T) {
+  q := bn256.Order
+  binaryOpParams := BinaryOpParams{A: fmt.Sprintf("0x%x", new(big.Int).Sub(q, big.NewInt(1))), B: "0x5"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/fr/add/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := big.NewInt(4)
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrSub(t *testing.T) {
+  q := bn256.Order
+  binaryOpParams := BinaryOpParams{A: "0x1", B: "0x2"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/fr/sub/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := new(big.Int).Sub(q, big.NewInt(1))
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrMul(t *testing.T) {
+  q := bn256.Order
+  binaryOpParams := BinaryOpParams{A: fmt.Sprintf("0x%x", new(big.Int).Sub(q, big.NewInt(1))), B: fmt.Sprintf("0x%x", new(big.Int).Sub(q, big.NewInt(1)))}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/fr/mul/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := big.NewInt(1)
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrInv(t *testing.T) {
+  q := bn256.Order
+  number := Number{V: "0x2"}
+  marshalledJSON, _ := json.Marshal(number)
+  response, err := http.Post("http://localhost:" + port + "/fr/inv/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := new(big.Int).ModInverse(big.NewInt(2), q)
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFpNeg(t *testing.T) {
+  p := bn256.P
+  number := Number{V: "0x1"}
+  marshalledJSON, _ := json.Marshal(number)
+  response, err := http.Post("http://localhost:" + port + "/fp/neg/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := new(big.Int).Sub(p, big.NewInt(1))
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrPow(t *testing.T) {
+  q := bn256.Order
+  binaryOpParams := BinaryOpParams{A: "0x3", B: "0x100"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/fr/pow/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  ans := new(big.Int).Exp(big.NewInt(3), big.NewInt(256), q)
+  if (res.Num.V != fmt.Sprintf("0x%x", ans)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFpSqrt(t *testing.T) {
+  p := bn256.P
+  number := Number{V: "0x2"}
+  marshalledJSON, _ := json.Marshal(number)
+  response, err := http.Post("http://localhost:" + port + "/fp/sqrt/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  root, err := NewBigInt(res.Num.V, nil)
+  if err != nil || new(big.Int).Exp(root, big.NewInt(2), p).Cmp(big.NewInt(2)) != 0 {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrBatchInv(t *testing.T) {
+  q := bn256.Order
+  numbers := Numbers{V: []*Number{&Number{V: "0x2"}, &Number{V: "0x3"}, &Number{V: "0x4"}}}
+  marshalledJSON, _ := json.Marshal(numbers)
+  response, err := http.Post("http://localhost:" + port + "/fr/batchinv/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Nums) != 3) {
+    t.Errorf("Wrong number of values returned")
+    return
+  }
+  for i, num := range numbers.V {
+    a, _ := NewBigInt(num.V, nil)
+    if (res.Nums[i].V != fmt.Sprintf("0x%x", new(big.Int).ModInverse(a, q))) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+}
+
+func TestFrNonCanonical(t *testing.T) {
+  q := bn256.Order
+  binaryOpParams := BinaryOpParams{A: fmt.Sprintf("0x%x", q), B: "0x1"}
+  marshalledJSON, _ := json.Marshal(binaryOpParams)
+  response, err := http.Post("http://localhost:" + port + "/fr/add/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err == nil || res.Err.Msg != ErrNonCanonical.Error() {
+    t.Errorf("Expected non-canonical input to be rejected\n")
+  }
+}
+
+func TestPolyEval(t *testing.T) {
+  polyParams := PolyParams{Poly: []*Number{&Number{V: "0x1"}, &Number{V: "0x2"}, &Number{V: "0x3"}}, X: &Number{V: "0x2"}}
+  marshalledJSON, _ := json.Marshal(polyParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/eval/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Num.V != "0x11") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestPolyMul(t *testing.T) {
+  binaryPolyParams := BinaryPolyParams{A: []*Number{&Number{V: "0x1"}, &Number{V: "0x1"}}, B: []*Number{&Number{V: "0x1"}, &Number{V: "0x1"}}}
+  marshalledJSON, _ := json.Marshal(binaryPolyParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/mul/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  expected := []string{"0x1", "0x2", "0x1"}
+  if (len(res.Poly) != len(expected)) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  for i := range expected {
+    if (res.Poly[i].V != expected[i]) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+}
+
+func TestPolyMulNTT(t *testing.T) {
+  q := bn256.Order
+  a := make([]*Number, 40)
+  b := make([]*Number, 40)
+  for i := range a {
+    ai, _ := rand.Int(rand.Reader, q)
+    bi, _ := rand.Int(rand.Reader, q)
+    a[i] = NewNumber(ai)
+    b[i] = NewNumber(bi)
+  }
+  binaryPolyParams := BinaryPolyParams{A: a, B: b}
+  marshalledJSON, _ := json.Marshal(binaryPolyParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/mul/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Poly) != 79) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  x, _ := rand.Int(rand.Reader, q)
+  aPoly, err := NewFieldElements(a, q, nil)
+  bPoly, err := NewFieldElements(b, q, err)
+  ans, err := NewFieldElements(res.Poly, q, err)
+  if err != nil {
+    t.Errorf("An error occurred while reading coefficients: %s\n", err)
+    return
+  }
+  expected := new(big.Int).Mul(PolyEval(aPoly, x), PolyEval(bPoly, x))
+  if (PolyEval(ans, x).Cmp(expected.Mod(expected, q)) != 0) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestPolyDivide(t *testing.T) {
+  polyParams := PolyParams{Poly: []*Number{&Number{V: "0x3"}, &Number{V: "0x0"}, &Number{V: "0x0"}, &Number{V: "0x1"}, &Number{V: "0x5"}}, N: &Number{V: "0x2"}}
+  marshalledJSON, _ := json.Marshal(polyParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/divide/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  expected := []string{"0x5", "0x1", "0x5"}
+  if (len(res.Poly) != len(expected)) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  for i := range expected {
+    if (res.Poly[i].V != expected[i]) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+  expected = []string{"0x8", "0x1"}
+  if (len(res.Rem) != len(expected)) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  for i := range expected {
+    if (res.Rem[i].V != expected[i]) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+}
+
+func TestPolyInterpolate(t *testing.T) {
+  interpolationParams := InterpolationParams{Xs: []*Number{&Number{V: "0x1"}, &Number{V: "0x2"}, &Number{V: "0x3"}}, Ys: []*Number{&Number{V: "0x6"}, &Number{V: "0x11"}, &Number{V: "0x22"}}}
+  marshalledJSON, _ := json.Marshal(interpolationParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/interpolate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  expected := []string{"0x1", "0x2", "0x3"}
+  if (len(res.Poly) != len(expected)) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  for i := range expected {
+    if (res.Poly[i].V != expected[i]) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+}
+
+func TestPolyNTT(t *testing.T) {
+  polyParams := PolyParams{Poly: []*Number{&Number{V: "0x1"}, &Number{V: "0x2"}}}
+  marshalledJSON, _ := json.Marshal(polyParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/ntt/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  expected := []string{"0x3", "0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000"}
+  if (len(res.Poly) != len(expected)) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  for i := range expected {
+    if (res.Poly[i].V != expected[i]) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+}
+
+func TestPolyINTT(t *testing.T) {
+  polyParams := PolyParams{Poly: []*Number{&Number{V: "0x3"}, &Number{V: "0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000000"}}}
+  marshalledJSON, _ := json.Marshal(polyParams)
+  response, err := http.Post("http://localhost:" + port + "/poly/intt/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  expected := []string{"0x1", "0x2"}
+  if (len(res.Poly) != len(expected)) {
+    t.Errorf("Wrong number of coefficients returned")
+    return
+  }
+  for i := range expected {
+    if (res.Poly[i].V != expected[i]) {
+      t.Errorf("Wrong answer returned at index %d", i)
+      return
+    }
+  }
+}
+
+func TestSSSSplit(t *testing.T) {
+  splitInputs := SplitInputs{Secret: "0x1234", T: 3, N: 5}
+  marshalledJSON, _ := json.Marshal(splitInputs)
+  response, err := http.Post("http://localhost:" + port + "/sss/split/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Shares) != 5) {
+    t.Errorf("Wrong number of shares returned")
+    return
+  }
+  xs, ys, err := NewShares([]*Share{res.Shares[4], res.Shares[0], res.Shares[2]}, nil)
+  secret, err := CombineShares(xs, ys, err)
+  if (err != nil || secret.Cmp(big.NewInt(0x1234)) != 0) {
+    t.Errorf("Shares do not reconstruct the secret")
+    return
+  }
+}
+
+func TestSSSCombine(t *testing.T) {
+  // shares of f(x) = 5 + 2x
+  combineInputs := CombineInputs{Shares: []*Share{&Share{I: 1, V: "0x7"}, &Share{I: 3, V: "0xb"}}}
+  marshalledJSON, _ := json.Marshal(combineInputs)
+  response, err := http.Post("http://localhost:" + port + "/sss/combine/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Num.V != "0x5") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestVSSFeldmanSplit(t *testing.T) {
+  vssSplitInputs := VSSSplitInputs{Secret: "0x1234", T: 2, N: 3}
+  marshalledJSON, _ := json.Marshal(vssSplitInputs)
+  response, err := http.Post("http://localhost:" + port + "/vss/feldman/split/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Shares) != 3 || len(res.Commitments) != 2) {
+    t.Errorf("Wrong number of shares or commitments returned")
+    return
+  }
+  G, H, err := PedersenGenerators(nil, nil, nil)
+  commitments := make([]*bn256.G1, len(res.Commitments))
+  for j := range commitments {
+    commitments[j], err = NewECPointFromCurvePoint(res.Commitments[j], err)
+  }
+  for _, share := range res.Shares {
+    expected, err := ShareCommitment(big.NewInt(int64(share.I)), commitments, err)
+    v, err := NewFieldElement(share.V, bn256.Order, err)
+    b := new(big.Int)
+    if (false) {
+      b, err = NewFieldElement(share.B, bn256.Order, err)
+    }
+    if err != nil {
+      t.Errorf("An error occurred while reading share %d: %s\n", share.I, err)
+      return
+    }
+    if !bytes.Equal(PedersenCommit(G, H, v, b).Marshal(), expected.Marshal()) {
+      t.Errorf("Share %d does not match the commitments", share.I)
+      return
+    }
+  }
+}
+
+func TestVSSPedersenSplit(t *testing.T) {
+  vssSplitInputs := VSSSplitInputs{Secret: "0x1234", T: 2, N: 3}
+  marshalledJSON, _ := json.Marshal(vssSplitInputs)
+  response, err := http.Post("http://localhost:" + port + "/vss/pedersen/split/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (len(res.Shares) != 3 || len(res.Commitments) != 2) {
+    t.Errorf("Wrong number of shares or commitments returned")
+    return
+  }
+  G, H, err := PedersenGenerators(nil, nil, nil)
+  commitments := make([]*bn256.G1, len(res.Commitments))
+  for j := range commitments {
+    commitments[j], err = NewECPointFromCurvePoint(res.Commitments[j], err)
+  }
+  for _, share := range res.Shares {
+    expected, err := ShareCommitment(big.NewInt(int64(share.I)), commitments, err)
+    v, err := NewFieldElement(share.V, bn256.Order, err)
+    b := new(big.Int)
+    if (true) {
+      b, err = NewFieldElement(share.B, bn256.Order, err)
+    }
+    if err != nil {
+      t.Errorf("An error occurred while reading share %d: %s\n", share.I, err)
+      return
+    }
+    if !bytes.Equal(PedersenCommit(G, H, v, b).Marshal(), expected.Marshal()) {
+      t.Errorf("Share %d does not match the commitments", share.I)
+      return
+    }
+  }
+}
+
+func TestVSSVerify(t *testing.T) {
+  // f(x) = 5 + 2x, share 3 is f(3) = 11
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  vssVerifyInputs := VSSVerifyInputs{
+    Scheme: "feldman",
+    Share: &Share{I: 3, V: "0xb"},
+    Commitments: []*CurvePoint{NewCurvePoint(new(bn256.G1).ScalarMult(G, big.NewInt(5))), NewCurvePoint(new(bn256.G1).ScalarMult(G, big.NewInt(2)))},
+  }
+  marshalledJSON, _ := json.Marshal(vssVerifyInputs)
+  response, err := http.Post("http://localhost:" + port + "/vss/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestVSSVerifyCheatingDealer(t *testing.T) {
+  // f(x) = 5 + 2x, share 3 is f(3) = 11
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  vssVerifyInputs := VSSVerifyInputs{
+    Scheme: "feldman",
+    Share: &Share{I: 3, V: "0xc"},
+    Commitments: []*CurvePoint{NewCurvePoint(new(bn256.G1).ScalarMult(G, big.NewInt(5))), NewCurvePoint(new(bn256.G1).ScalarMult(G, big.NewInt(2)))},
+  }
+  marshalledJSON, _ := json.Marshal(vssVerifyInputs)
+  response, err := http.Post("http://localhost:" + port + "/vss/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "false") {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestFrostCommit(t *testing.T) {
+  frostCommitInputs := FrostCommitInputs{I: 1}
+  marshalledJSON, _ := json.Marshal(frostCommitInputs)
+  response, err := http.Post("http://localhost:" + port + "/frost/commit/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  d, err := NewBigInt(res.Nonces.D, nil)
+  e, err := NewBigInt(res.Nonces.E, err)
+  if err != nil {
+    t.Errorf("An error occurred while reading nonces: %s\n", err)
+    return
+  }
+  D := NewCurvePoint(new(bn256.G1).ScalarBaseMult(d))
+  E := NewCurvePoint(new(bn256.G1).ScalarBaseMult(e))
+  if (res.NonceCommitment.I != 1 || *res.NonceCommitment.D != *D || *res.NonceCommitment.E != *E) {
+    t.Errorf("Commitment does not match the nonces")
+    return
+  }
+}
+
+func TestFrostSign(t *testing.T) {
+  // 2-of-3 sharing of x, signed by signers 1 and 3
+  x := big.NewInt(0x1234abcd)
+  shares, _, _ := SplitSecret(x, 2, 3, nil)
+  P := new(bn256.G1).ScalarBaseMult(x)
+  M := "message to sign"
+  d1, e1, _ := GenerateNoncePair()
+  d3, e3, _ := GenerateNoncePair()
+  commitments := []*NonceCommitment{
+    &NonceCommitment{I: 1, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d1)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e1))},
+    &NonceCommitment{I: 3, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d3)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e3))},
+  }
+  signers, _ := NewSignerCommitments(commitments, nil)
+  frostSignInputs := FrostSignInputs{
+    I: 3,
+    Share: fmt.Sprintf("0x%064x", shares[2]),
+    Nonces: &NoncePair{D: fmt.Sprintf("0x%064x", d3), E: fmt.Sprintf("0x%064x", e3)},
+    P: NewCurvePoint(P),
+    M: M,
+    Commitments: commitments,
+  }
+  marshalledJSON, _ := json.Marshal(frostSignInputs)
+  response, err := http.Post("http://localhost:" + port + "/frost/sign/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  z, _ := FrostPartialSign(3, shares[2], d3, e3, P, M, signers, nil)
+  if (res.Partial.I != 3 || res.Partial.Z != fmt.Sprintf("0x%064x", z) || *res.Partial.Y != *NewCurvePoint(new(bn256.G1).ScalarBaseMult(shares[2]))) {
+    t.Errorf("Wrong partial signature returned")
+    return
+  }
+}
+
+func TestFrostBindingFactorsBindKey(t *testing.T) {
+  d, e, _ := GenerateNoncePair()
+  commitments := []*NonceCommitment{&NonceCommitment{I: 1, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e))}}
+  signers, _ := NewSignerCommitments(commitments, nil)
+  rhos1 := FrostBindingFactors(new(bn256.G1).ScalarBaseMult(big.NewInt(1)), "message to sign", signers)
+  rhos2 := FrostBindingFactors(new(bn256.G1).ScalarBaseMult(big.NewInt(2)), "message to sign", signers)
+  if (rhos1[0].Cmp(rhos2[0]) == 0) {
+    t.Errorf("Binding factors do not depend on the group public key")
+    return
+  }
+}
+
+func TestFrostAggregate(t *testing.T) {
+  // 2-of-3 sharing of x, signed by signers 1 and 3
+  x := big.NewInt(0x1234abcd)
+  shares, _, _ := SplitSecret(x, 2, 3, nil)
+  P := new(bn256.G1).ScalarBaseMult(x)
+  M := "message to sign"
+  d1, e1, _ := GenerateNoncePair()
+  d3, e3, _ := GenerateNoncePair()
+  commitments := []*NonceCommitment{
+    &NonceCommitment{I: 1, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d1)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e1))},
+    &NonceCommitment{I: 3, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d3)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e3))},
+  }
+  signers, _ := NewSignerCommitments(commitments, nil)
+  z1, _ := FrostPartialSign(1, shares[0], d1, e1, P, M, signers, nil)
+  z3, _ := FrostPartialSign(3, shares[2], d3, e3, P, M, signers, nil)
+  frostAggregateInputs := FrostAggregateInputs{
+    P: NewCurvePoint(P),
+    M: M,
+    Commitments: commitments,
+    Partials: []*PartialSignature{
+      &PartialSignature{I: 1, Z: fmt.Sprintf("0x%064x", z1), Y: NewCurvePoint(new(bn256.G1).ScalarBaseMult(shares[0]))},
+      &PartialSignature{I: 3, Z: fmt.Sprintf("0x%064x", z3), Y: NewCurvePoint(new(bn256.G1).ScalarBaseMult(shares[2]))},
+    },
+  }
+  marshalledJSON, _ := json.Marshal(frostAggregateInputs)
+  response, err := http.Post("http://localhost:" + port + "/frost/aggregate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  E, err := NewBigInt(res.Sig.E, nil)
+  S, err := NewBigInt(res.Sig.S, err)
+  isValid, err := VerifySchnorrSignature(P, res.Sig.M, E, S, err)
+  if (err != nil || !isValid) {
+    t.Errorf("Aggregate signature does not verify")
+    return
+  }
+}
+
+func TestFrostAggregateInvalidPartial(t *testing.T) {
+  // signer 3 sends a partial signature that is off by one
+  x := big.NewInt(0x1234abcd)
+  shares, _, _ := SplitSecret(x, 2, 3, nil)
+  P := new(bn256.G1).ScalarBaseMult(x)
+  M := "message to sign"
+  d1, e1, _ := GenerateNoncePair()
+  d3, e3, _ := GenerateNoncePair()
+  commitments := []*NonceCommitment{
+    &NonceCommitment{I: 1, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d1)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e1))},
+    &NonceCommitment{I: 3, D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(d3)), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(e3))},
+  }
+  signers, _ := NewSignerCommitments(commitments, nil)
+  z1, _ := FrostPartialSign(1, shares[0], d1, e1, P, M, signers, nil)
+  z3, _ := FrostPartialSign(3, shares[2], d3, e3, P, M, signers, nil)
+  z3.Add(z3, big.NewInt(1)).Mod(z3, bn256.Order)
+  frostAggregateInputs := FrostAggregateInputs{
+    P: NewCurvePoint(P),
+    M: M,
+    Commitments: commitments,
+    Partials: []*PartialSignature{
+      &PartialSignature{I: 1, Z: fmt.Sprintf("0x%064x", z1), Y: NewCurvePoint(new(bn256.G1).ScalarBaseMult(shares[0]))},
+      &PartialSignature{I: 3, Z: fmt.Sprintf("0x%064x", z3), Y: NewCurvePoint(new(bn256.G1).ScalarBaseMult(shares[2]))},
+    },
+  }
+  marshalledJSON, _ := json.Marshal(frostAggregateInputs)
+  response, err := http.Post("http://localhost:" + port + "/frost/aggregate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if (res.Err == nil || res.Err.Msg != "Invalid partial signature from signer 3") {
+    t.Errorf("Invalid partial signature not traced to its signer")
+    return
+  }
+}
+
+func TestMuSig2KeyAgg(t *testing.T) {
+  keys := []*bn256.G1{new(bn256.G1).ScalarBaseMult(big.NewInt(11)), new(bn256.G1).ScalarBaseMult(big.NewInt(22))}
+  muSig2KeyAggInputs := MuSig2KeyAggInputs{Keys: []*CurvePoint{NewCurvePoint(keys[0]), NewCurvePoint(keys[1])}}
+  marshalledJSON, _ := json.Marshal(muSig2KeyAggInputs)
+  response, err := http.Post("http://localhost:" + port + "/musig2/keyagg/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  coeffs, X, _ := MuSig2KeyCoefficients(keys, nil)
+  if (*res.P != *NewCurvePoint(X) || len(res.Nums) != 2 || res.Nums[0].V != NewNumber(coeffs[0]).V || res.Nums[1].V != NewNumber(coeffs[1]).V) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+}
+
+func TestMuSig2Nonce(t *testing.T) {
+  response, err := http.Get("http://localhost:" + port + "/musig2/nonce")
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  d, err := NewBigInt(res.Nonces.D, nil)
+  e, err := NewBigInt(res.Nonces.E, err)
+  if err != nil {
+    t.Errorf("An error occurred while reading nonces: %s\n", err)
+    return
+  }
+  if (*res.NonceCommitment.D != *NewCurvePoint(new(bn256.G1).ScalarBaseMult(d)) || *res.NonceCommitment.E != *NewCurvePoint(new(bn256.G1).ScalarBaseMult(e))) {
+    t.Errorf("Commitment does not match the nonces")
+    return
+  }
+}
+
+func TestMuSig2Sign(t *testing.T) {
+  privs := []*big.Int{big.NewInt(11), big.NewInt(22), big.NewInt(33)}
+  keys := make([]*bn256.G1, len(privs))
+  curvePoints := make([]*CurvePoint, len(privs))
+  ds := make([]*big.Int, len(privs))
+  es := make([]*big.Int, len(privs))
+  commitments := make([]*NonceCommitment, len(privs))
+  for i := range privs {
+    keys[i] = new(bn256.G1).ScalarBaseMult(privs[i])
+    curvePoints[i] = NewCurvePoint(keys[i])
+    ds[i], es[i], _ = GenerateNoncePair()
+    commitments[i] = &NonceCommitment{D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(ds[i])), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(es[i]))}
+  }
+  nonces, _ := NewNonceCommitments(commitments, len(privs), nil)
+  M := "message to sign"
+  muSig2SignInputs := MuSig2SignInputs{
+    Priv: fmt.Sprintf("0x%064x", privs[1]),
+    Nonces: &NoncePair{D: fmt.Sprintf("0x%064x", ds[1]), E: fmt.Sprintf("0x%064x", es[1])},
+    Keys: curvePoints,
+    M: M,
+    Commitments: commitments,
+  }
+  marshalledJSON, _ := json.Marshal(muSig2SignInputs)
+  response, err := http.Post("http://localhost:" + port + "/musig2/sign/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  s, _, _ := MuSig2PartialSign(privs[1], ds[1], es[1], keys, M, nonces, nil)
+  if (res.Partial.I != 2 || res.Partial.Z != fmt.Sprintf("0x%064x", s)) {
+    t.Errorf("Wrong partial signature returned")
+    return
+  }
+}
+
+func TestMuSig2Aggregate(t *testing.T) {
+  privs := []*big.Int{big.NewInt(11), big.NewInt(22), big.NewInt(33)}
+  keys := make([]*bn256.G1, len(privs))
+  curvePoints := make([]*CurvePoint, len(privs))
+  ds := make([]*big.Int, len(privs))
+  es := make([]*big.Int, len(privs))
+  commitments := make([]*NonceCommitment, len(privs))
+  for i := range privs {
+    keys[i] = new(bn256.G1).ScalarBaseMult(privs[i])
+    curvePoints[i] = NewCurvePoint(keys[i])
+    ds[i], es[i], _ = GenerateNoncePair()
+    commitments[i] = &NonceCommitment{D: NewCurvePoint(new(bn256.G1).ScalarBaseMult(ds[i])), E: NewCurvePoint(new(bn256.G1).ScalarBaseMult(es[i]))}
+  }
+  nonces, _ := NewNonceCommitments(commitments, len(privs), nil)
+  M := "message to sign"
+  partials := make([]*PartialSignature, len(privs))
+  for i := range privs {
+    s, _, _ := MuSig2PartialSign(privs[i], ds[i], es[i], keys, M, nonces, nil)
+    partials[i] = &PartialSignature{I: i+1, Z: fmt.Sprintf("0x%064x", s)}
+  }
+  muSig2AggregateInputs := MuSig2AggregateInputs{Keys: curvePoints, M: M, Commitments: commitments, Partials: partials}
+  marshalledJSON, _ := json.Marshal(muSig2AggregateInputs)
+  response, err := http.Post("http://localhost:" + port + "/musig2/aggregate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  _, X, _ := MuSig2KeyCoefficients(keys, nil)
+  E, err := NewBigInt(res.Sig.E, nil)
+  S, err := NewBigInt(res.Sig.S, err)
+  isValid, err := VerifySchnorrSignature(X, res.Sig.M, E, S, err)
+  if (err != nil || !isValid || *res.Sig.P != *NewCurvePoint(X)) {
+    t.Errorf("Aggregate signature does not verify under the aggregate key")
+    return
+  }
+}
+
+func TestGenerateSchnorrDeterministic(t *testing.T) {
+  // RFC 6979 A.2.5, P-256 with SHA-256 and message "sample"
+  generateSchnorrInputs := GenerateSchnorrInputs{Priv: "0xc9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", M: "sample", Nonce: "deterministic"}
+  marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/schnorr/?curve=p256", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  x, _ := new(big.Int).SetString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", 16)
+  h := sha256.Sum256([]byte("sample"))
+  k := RFC6979Nonce(P256.Params().N, x, h[:], nil)
+  if (fmt.Sprintf("%064x", k) != "a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60") {
+    t.Errorf("Nonce does not match the RFC 6979 test vector")
+    return
+  }
+  k = RFC6979Nonce(P256.Params().N, x, h[:], []byte("p256/legacy"))
+  kG := P256.BaseMul(k).CurvePoint()
+  if (*res.Sig.K != *kG) {
+    t.Errorf("Nonce does not match the RFC 6979 nonce with the curve and scheme as additional data")
+    return
+  }
+}
+
+func TestGenerateSchnorrDeterministicSchemes(t *testing.T) {
+  var kGs []*CurvePoint
+  for _, scheme := range []string{"legacy", "binary-sha256"} {
+    generateSchnorrInputs := GenerateSchnorrInputs{Priv: "0xc9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", M: "sample", Nonce: "deterministic", Scheme: scheme}
+    marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+    response, err := http.Post("http://localhost:" + port + "/generate/schnorr/?curve=p256", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    kGs = append(kGs, res.Sig.K)
+  }
+  if (*kGs[0] == *kGs[1]) {
+    t.Errorf("The same nonce was used under two challenge schemes")
+    return
+  }
+}
+
+func TestGenerateSchnorrDeterministicLongKey(t *testing.T) {
+  // a 33 byte key signs as the key reduced mod N
+  x, _ := new(big.Int).SetString("01c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", 16)
+  cases := []string{fmt.Sprintf("0x%066x", x), fmt.Sprintf("0x%x", P256.Params().N)}
+  for i, priv := range cases {
+    generateSchnorrInputs := GenerateSchnorrInputs{Priv: priv, M: "sample", Nonce: "deterministic"}
+    marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+    response, err := http.Post("http://localhost:" + port + "/generate/schnorr/?curve=p256", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if i == 1 {
+      if (res.Err == nil || res.Err.Msg == "") {
+        t.Errorf("Key equal to the group order not rejected\n")
+      }
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    reduced := new(big.Int).Mod(x, P256.Params().N)
+    h := sha256.Sum256([]byte("sample"))
+    k := RFC6979Nonce(P256.Params().N, reduced, h[:], []byte("p256/legacy"))
+    if (*res.Sig.K != *P256.BaseMul(k).CurvePoint() || *res.Sig.P != *P256.BaseMul(reduced).CurvePoint()) {
+      t.Errorf("Long key does not sign as the reduced key")
+      return
+    }
+  }
+}
+
+func TestGenerateSchnorrHedged(t *testing.T) {
+  x, _ := new(big.Int).SetString("0644e7fe131b029b85045b48181885d978163916871cffd3c208c16d87cfd3", 16)
+  generateSchnorrInputs := GenerateSchnorrInputs{Priv: fmt.Sprintf("0x%x", x), M: "message to sign", Nonce: "hedged"}
+  marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/schnorr/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  E, err := NewBigInt(res.Sig.E, nil)
+  S, err := NewBigInt(res.Sig.S, err)
+  isValid, err := VerifySchnorrSignature(new(bn256.G1).ScalarBaseMult(x), res.Sig.M, E, S, err)
+  if (err != nil || !isValid) {
+    t.Errorf("Signature does not verify")
+    return
+  }
+  h := sha256.Sum256([]byte(res.Sig.M))
+  k := RFC6979Nonce(bn256.Order, x, h[:], []byte("bn256/legacy"))
+  if (*res.Sig.K == *NewCurvePoint(new(bn256.G1).ScalarBaseMult(k))) {
+    t.Errorf("Hedged nonce equals the deterministic nonce")
+    return
+  }
+}
+
+func TestVerifySchnorrBatch(t *testing.T) {
+  sigs := make([]*SchnorrSignature, 8)
+  for i := range sigs {
+    P, kG, M, E, S, _ := GenerateSchnorrSignature(fmt.Sprintf("message %d", i), big.NewInt(int64(1000+i)), nil)
+    sigs[i] = &SchnorrSignature{P: NewCurvePoint(P), K: NewCurvePoint(kG), M: M, E: fmt.Sprintf("0x%064x", E), S: fmt.Sprintf("0x%064x", S)}
+  }
+  marshalledJSON, _ := json.Marshal(sigs)
+  response, err := http.Post("http://localhost:" + port + "/verify/schnorr/batch/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true" || len(res.Valid) != len(sigs)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+  for i := range sigs {
+    if (res.Valid[i] != (i != -1)) {
+      t.Errorf("Wrong result for signature %d", i)
+      return
+    }
+  }
+}
+
+func TestVerifySchnorrBatchInvalid(t *testing.T) {
+  sigs := make([]*SchnorrSignature, 8)
+  for i := range sigs {
+    P, kG, M, E, S, _ := GenerateSchnorrSignature(fmt.Sprintf("message %d", i), big.NewInt(int64(1000+i)), nil)
+    sigs[i] = &SchnorrSignature{P: NewCurvePoint(P), K: NewCurvePoint(kG), M: M, E: fmt.Sprintf("0x%064x", E), S: fmt.Sprintf("0x%064x", S)}
+  }
+  sigs[5].S = sigs[6].S
+  marshalledJSON, _ := json.Marshal(sigs)
+  response, err := http.Post("http://localhost:" + port + "/verify/schnorr/batch/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "false" || len(res.Valid) != len(sigs)) {
+    t.Errorf("Wrong answer returned")
+    return
+  }
+  for i := range sigs {
+    if (res.Valid[i] != (i != 5)) {
+      t.Errorf("Wrong result for signature %d", i)
+      return
+    }
+  }
+}
+
+func TestGenerateSchnorrBinarySha256(t *testing.T) {
+  x := big.NewInt(123456789)
+  generateSchnorrInputs := GenerateSchnorrInputs{Priv: fmt.Sprintf("0x%x", x), M: "message to sign", Scheme: "binary-sha256"}
+  marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/schnorr/?curve=secp256k1", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if res.Sig.Scheme != "binary-sha256" {
+    t.Errorf("Signature does not record the challenge scheme")
+    return
+  }
+  P, err := Secp256k1.NewPoint(res.Sig.P, nil)
+  kG, err := Secp256k1.NewPoint(res.Sig.K, err)
+  E, err := NewBigInt(res.Sig.E, err)
+  S, err := NewBigInt(res.Sig.S, err)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  dst := "ECC-API-SCHNORR-V01-secp256k1-sha256"
+  preimage := append([]byte{byte(len(dst))}, []byte(dst)...)
+  preimage = append(preimage, 0, 0, 0, 0, 0, 0, 0, byte(len(res.Sig.M)))
+  preimage = append(preimage, []byte(res.Sig.M)...)
+  preimage = append(preimage, PointBytes(P)...)
+  preimage = append(preimage, PointBytes(kG)...)
+  h := sha256.Sum256(preimage)
+  e := new(big.Int).Mod(new(big.Int).SetBytes(h[:]), Secp256k1.Params().N)
+  if (e.Cmp(E) != 0) {
+    t.Errorf("Wrong challenge")
+    return
+  }
+  isValid, err := VerifyCurveSchnorrSignatureWithScheme(Secp256k1, "binary-sha256", Secp256k1.BaseMul(x), res.Sig.M, E, S, nil)
+  if (err != nil || !isValid) {
+    t.Errorf("Signature does not verify")
+    return
+  }
+  isValid, err = VerifyCurveSchnorrSignature(Secp256k1, Secp256k1.BaseMul(x), res.Sig.M, E, S, nil)
+  if (err != nil || isValid) {
+    t.Errorf("Signature verifies under the legacy scheme")
+    return
+  }
+}
+
+func TestGenerateSchnorrSecp256k1(t *testing.T) {
+  generateSchnorrInputs := GenerateSchnorrInputs{Priv: "0x075bcd15", M: "message to sign"}
+  marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/schnorr/?curve=secp256k1", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  x := big.NewInt(123456789)
+  P, err := Secp256k1.NewPoint(res.Sig.P, nil)
+  E, err := NewBigInt(res.Sig.E, err)
+  S, err := NewBigInt(res.Sig.S, err)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  if (!P.Equal(Secp256k1.BaseMul(x))) {
+    t.Errorf("Wrong public key")
+    return
+  }
+  isValid, err := VerifyCurveSchnorrSignature(Secp256k1, P, res.Sig.M, E, S, nil)
+  if (err != nil || !isValid) {
+    t.Errorf("Signature does not verify")
+    return
+  }
+}
+
+func TestVerifySchnorrSecp256k1(t *testing.T) {
+  // signatures made elsewhere can still be verified on secp256k1
+  x := big.NewInt(123456789)
+  k := big.NewInt(987654321)
+  P := Secp256k1.BaseMul(x)
+  kG := Secp256k1.BaseMul(k)
+  e, _ := SchnorrChallengeWithScheme(Secp256k1, "binary-sha256", "message to sign", P, kG)
+  s := new(big.Int).Mul(e, x)
+  s.Add(s, k).Mod(s, Secp256k1.Params().N)
+  sig := SchnorrSignature{P: P.CurvePoint(), K: kG.CurvePoint(), M: "message to sign", E: fmt.Sprintf("0x%064x", e), S: fmt.Sprintf("0x%064x", s), Scheme: "binary-sha256"}
+  marshalledJSON, _ := json.Marshal(sig)
+  response, err := http.Post("http://localhost:" + port + "/verify/schnorr/?curve=secp256k1", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Signature does not verify")
+    return
+  }
+}
+
+func TestConstantTimeScalarMult(t *testing.T) {
+  // the constant time path agrees with the generic affine arithmetic
+  for _, curve := range []*WeierstrassCurve{P256, Secp256k1} {
+    N := curve.Params().N
+    G := &WeierstrassPoint{curve: curve, X: curve.Params().Gx, Y: curve.Params().Gy}
+    if (!curve.BaseMul(big.NewInt(2)).Equal(G.Add(G)) || !curve.BaseMul(big.NewInt(3)).Equal(G.Add(G).Add(G))) {
+      t.Errorf("Wrong small multiples of G on %s", curve.Params().Name)
+      return
+    }
+    if (!curve.BaseMul(new(big.Int).Sub(N, big.NewInt(1))).Equal(G.Neg()) || !curve.BaseMul(N).IsInfinity() || !curve.BaseMul(big.NewInt(-1)).Equal(G.Neg())) {
+      t.Errorf("Scalars are not reduced mod N on %s", curve.Params().Name)
+      return
+    }
+    a, _ := new(big.Int).SetString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721", 16)
+    b := big.NewInt(0x1234567)
+    if (!curve.BaseMul(a).Mul(b).Equal(curve.BaseMul(new(big.Int).Mul(a, b))) || !curve.Identity().Mul(b).IsInfinity() || !G.Mul(N).IsInfinity()) {
+      t.Errorf("Wrong multiple of a point on %s", curve.Params().Name)
+      return
+    }
+  }
+}
+
+func TestVerifySchnorrSolidity(t *testing.T) {
+  P, kG, M, E, S, err := GenerateCurveSchnorrSignature(BN256, "message to sign", big.NewInt(42), "random", "solidity", nil)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  h := sha3.NewKeccak256()
+  h.Write(append(append(PointBytes(P), PointBytes(kG)...), []byte(M)...))
+  e := new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), bn256.Order)
+  if (e.Cmp(E) != 0) {
+    t.Errorf("Wrong challenge")
+    return
+  }
+  schnorrSignature := SchnorrSignature{P: P.CurvePoint(), M: M, E: fmt.Sprintf("0x%064x", E), S: fmt.Sprintf("0x%064x", S), Scheme: "solidity"}
+  marshalledJSON, _ := json.Marshal(schnorrSignature)
+  response, err := http.Post("http://localhost:" + port + "/verify/schnorr/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (res.Text != "true") {
+    t.Errorf("Signature does not verify")
+    return
+  }
+}
+
+func TestGenerateSchnorrUnknownScheme(t *testing.T) {
+  generateSchnorrInputs := GenerateSchnorrInputs{Priv: "0x05", M: "message to sign", Scheme: "md5"}
+  marshalledJSON, _ := json.Marshal(generateSchnorrInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/schnorr/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err == nil || res.Err.Msg != "Unknown challenge scheme: md5" {
+    t.Errorf("Expected an unknown scheme error")
+    return
+  }
+}
+
+func TestGenerateVectorCommitment(t *testing.T) {
+  vectorCommitmentInputs := VectorCommitmentInputs{V: []string{"0x01", "0x02", "0x03"}, B: "0x2a"}
+  marshalledJSON, _ := json.Marshal(vectorCommitmentInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/commitment/vector/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  _, H, err := PedersenGenerators(nil, nil, nil)
+  expected := new(bn256.G1).ScalarMult(H, big.NewInt(42))
+  for i := 0; i < 3; i++ {
+    G, err := NUMSGenerator(fmt.Sprintf("pedersen/G/%d", i), nil)
+    if i == 0 {
+      G = new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+    }
+    if err != nil {
+      t.Errorf("An error occurred: %s\n", err)
+      return
+    }
+    expected = new(bn256.G1).Add(expected, new(bn256.G1).ScalarMult(G, big.NewInt(int64(i+1))))
+  }
+  if (*res.P != *NewCurvePoint(expected)) {
+    t.Errorf("Wrong commitment")
+    return
+  }
+}
+
+func TestVerifyCommitment(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  H, _ := NUMSGenerator("some other generator", nil)
+  C := PedersenCommit(G, H, big.NewInt(5), big.NewInt(7))
+  openings := []CommitmentOpening{
+    CommitmentOpening{C: NewCurvePoint(C), V: []string{"0x05"}, B: "0x07", Gs: []*CurvePoint{NewCurvePoint(G)}, H: NewCurvePoint(H)},
+    CommitmentOpening{C: NewCurvePoint(C), V: []string{"0x05"}, B: "0x08", Gs: []*CurvePoint{NewCurvePoint(G)}, H: NewCurvePoint(H)},
+  }
+  expected := []string{"true", "false"}
+  for i, commitmentOpening := range openings {
+    marshalledJSON, _ := json.Marshal(commitmentOpening)
+    response, err := http.Post("http://localhost:" + port + "/verify/commitment/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    if (res.Text != expected[i]) {
+      t.Errorf("Wrong result for opening %d", i)
+      return
+    }
+  }
+}
+
+func TestVerifyCommitmentDefaultGenerators(t *testing.T) {
+  commitmentInputs := CommitmentInputs{V: "0x64", B: "0x0a"}
+  marshalledJSON, _ := json.Marshal(commitmentInputs)
+  response, err := http.Post("http://localhost:" + port + "/generate/commitment/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  commitmentOpening := CommitmentOpening{C: res.P, V: []string{"0x64"}, B: "0x0a"}
+  marshalledJSON, _ = json.Marshal(commitmentOpening)
+  response, err = http.Post("http://localhost:" + port + "/verify/commitment/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err = ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var verifyRes Response
+  err = json.Unmarshal(contents, &verifyRes)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if verifyRes.Err != nil && verifyRes.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", verifyRes.Err.Msg))
+    return
+  }
+  if (verifyRes.Text != "true") {
+    t.Errorf("Commitment with the default generators does not verify with the default generators")
+    return
+  }
+}
+
+func TestCommitmentAdd(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  _, H, _ := PedersenGenerators(nil, nil, nil)
+  C1 := PedersenCommit(G, H, big.NewInt(60), big.NewInt(3))
+  C2 := PedersenCommit(G, H, big.NewInt(40), big.NewInt(2))
+  C3 := PedersenCommit(G, H, big.NewInt(1), big.NewInt(1))
+  commitmentListInputs := CommitmentListInputs{Commitments: []*CurvePoint{NewCurvePoint(C1), NewCurvePoint(C2), NewCurvePoint(C3)}}
+  marshalledJSON, _ := json.Marshal(commitmentListInputs)
+  response, err := http.Post("http://localhost:" + port + "/commitment/add/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (*res.P != *NewCurvePoint(PedersenCommit(G, H, big.NewInt(101), big.NewInt(6)))) {
+    t.Errorf("Wrong sum")
+    return
+  }
+}
+
+func TestCommitmentSub(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  _, H, _ := PedersenGenerators(nil, nil, nil)
+  C1 := PedersenCommit(G, H, big.NewInt(100), big.NewInt(10))
+  C2 := PedersenCommit(G, H, big.NewInt(60), big.NewInt(3))
+  binaryEcOpParams := BinaryEcOpParams{A: NewCurvePoint(C1), B: NewCurvePoint(C2)}
+  marshalledJSON, _ := json.Marshal(binaryEcOpParams)
+  response, err := http.Post("http://localhost:" + port + "/commitment/sub/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  if (*res.P != *NewCurvePoint(PedersenCommit(G, H, big.NewInt(40), big.NewInt(7)))) {
+    t.Errorf("Wrong difference")
+    return
+  }
+}
+
+func TestCommitmentScale(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  _, H, _ := PedersenGenerators(nil, nil, nil)
+  C := PedersenCommit(G, H, big.NewInt(12), big.NewInt(5))
+  commitmentScaleInputs := CommitmentScaleInputs{C: NewCurvePoint(C), K: "-0x03"}
+  marshalledJSON, _ := json.Marshal(commitmentScaleInputs)
+  response, err := http.Post("http://localhost:" + port + "/commitment/scale/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  v := new(big.Int).Sub(bn256.Order, big.NewInt(36))
+  b := new(big.Int).Sub(bn256.Order, big.NewInt(15))
+  if (*res.P != *NewCurvePoint(PedersenCommit(G, H, v, b))) {
+    t.Errorf("Wrong multiple")
+    return
+  }
+}
+
+func TestCommitmentBalance(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  _, H, _ := PedersenGenerators(nil, nil, nil)
+  in := []*CurvePoint{NewCurvePoint(PedersenCommit(G, H, big.NewInt(100), big.NewInt(10)))}
+  out := []*CurvePoint{NewCurvePoint(PedersenCommit(G, H, big.NewInt(60), big.NewInt(3))), NewCurvePoint(PedersenCommit(G, H, big.NewInt(40), big.NewInt(2)))}
+  inflated := []*CurvePoint{out[0], NewCurvePoint(PedersenCommit(G, H, big.NewInt(41), big.NewInt(2)))}
+  balances := []CommitmentBalanceInputs{
+    CommitmentBalanceInputs{Inputs: in, Outputs: out, Excess: "0x05"},
+    CommitmentBalanceInputs{Inputs: in, Outputs: out, Excess: "0x04"},
+    CommitmentBalanceInputs{Inputs: in, Outputs: inflated, Excess: "0x05"},
+  }
+  expected := []string{"true", "false", "false"}
+  for i, commitmentBalanceInputs := range balances {
+    marshalledJSON, _ := json.Marshal(commitmentBalanceInputs)
+    response, err := http.Post("http://localhost:" + port + "/commitment/balance/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    if (res.Text != expected[i]) {
+      t.Errorf("Wrong result for case %d", i)
+      return
+    }
+  }
+}
+
+func TestRangeProofGenerate(t *testing.T) {
+  rangeProofInputs := RangeProofInputs{V: []string{"0x2a", "0xffffffff"}, B: []string{"0x07", "0x09"}, N: 32}
+  marshalledJSON, _ := json.Marshal(rangeProofInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/range/generate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  G, H, _ := PedersenGenerators(nil, nil, nil)
+  V0 := PedersenCommit(G, H, big.NewInt(42), big.NewInt(7))
+  V1 := PedersenCommit(G, H, big.NewInt(0xffffffff), big.NewInt(9))
+  if (len(res.Commitments) != 2 || *res.Commitments[0] != *NewCurvePoint(V0) || *res.Commitments[1] != *NewCurvePoint(V1)) {
+    t.Errorf("Wrong commitments")
+    return
+  }
+  if (len(res.RangeProof.L) != 6) {
+    t.Errorf("Wrong number of inner product rounds")
+    return
+  }
+  proof, err := NewRangeProofData(res.RangeProof, nil)
+  isValid, err := VerifyRangeProof(G, H, []*bn256.G1{V0, V1}, 32, proof, err)
+  if (err != nil || !isValid) {
+    t.Errorf("Range proof does not verify")
+    return
+  }
+}
+
+func TestRangeProofVerify(t *testing.T) {
+  G, H, _ := PedersenGenerators(nil, nil, nil)
+  Vs, proof, err := ProveRange(G, H, []*big.Int{big.NewInt(1000)}, []*big.Int{big.NewInt(12345)}, 16, nil)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  other := PedersenCommit(G, H, big.NewInt(1001), big.NewInt(12345))
+  cases := []RangeProofVerifyInputs{
+    RangeProofVerifyInputs{Commitments: []*CurvePoint{NewCurvePoint(Vs[0])}, N: 16, Proof: NewRangeProof(proof)},
+    RangeProofVerifyInputs{Commitments: []*CurvePoint{NewCurvePoint(other)}, N: 16, Proof: NewRangeProof(proof)},
+  }
+  expected := []string{"true", "false"}
+  for i, rangeProofVerifyInputs := range cases {
+    marshalledJSON, _ := json.Marshal(rangeProofVerifyInputs)
+    response, err := http.Post("http://localhost:" + port + "/proof/range/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    if (res.Text != expected[i]) {
+      t.Errorf("Wrong result for case %d", i)
+      return
+    }
+  }
+}
+
+func TestRangeProofOutOfRange(t *testing.T) {
+  rangeProofInputs := RangeProofInputs{V: []string{"-0x01"}, B: []string{"0x07"}, N: 8}
+  marshalledJSON, _ := json.Marshal(rangeProofInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/range/generate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err == nil || res.Err.Msg != "Value at index 0 is not in [0, 2^8)" {
+    t.Errorf("Expected an out of range error")
+    return
+  }
+}
+
+func TestDLogProofGenerate(t *testing.T) {
+  G, _ := NUMSGenerator("some other generator", nil)
+  x := big.NewInt(0x1234)
+  dlogProofInputs := DLogProofInputs{X: "0x1234", G: NewCurvePoint(G), Context: "key registration"}
+  marshalledJSON, _ := json.Marshal(dlogProofInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/dlog/generate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  P := new(bn256.G1).ScalarMult(G, x)
+  if (*res.P != *NewCurvePoint(P)) {
+    t.Errorf("Wrong public point")
+    return
+  }
+  R, err := NewECPointFromCurvePoint(res.DLogProof.R, nil)
+  s, err := NewBigInt(res.DLogProof.S, err)
+  isValid, err := VerifyDLog(G, P, R, s, "key registration", err)
+  if (err != nil || !isValid) {
+    t.Errorf("Proof does not verify")
+    return
+  }
+}
+
+func TestDLogProofVerify(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  P, R, s, err := ProveDLog(G, big.NewInt(987654321), "key registration", nil)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  proof := &DLogProof{R: NewCurvePoint(R), S: fmt.Sprintf("0x%064x", s)}
+  other := new(bn256.G1).ScalarBaseMult(big.NewInt(987654322))
+  cases := []DLogProofVerifyInputs{
+    DLogProofVerifyInputs{P: NewCurvePoint(P), Context: "key registration", Proof: proof},
+    DLogProofVerifyInputs{P: NewCurvePoint(P), Context: "another context", Proof: proof},
+    DLogProofVerifyInputs{P: NewCurvePoint(other), Context: "key registration", Proof: proof},
+  }
+  expected := []string{"true", "false", "false"}
+  for i, dlogProofVerifyInputs := range cases {
+    marshalledJSON, _ := json.Marshal(dlogProofVerifyInputs)
+    response, err := http.Post("http://localhost:" + port + "/proof/dlog/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    if (res.Text != expected[i]) {
+      t.Errorf("Wrong result for case %d", i)
+      return
+    }
+  }
+}
+
+func TestDLEQProofGenerate(t *testing.T) {
+  G := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+  H, _ := NUMSGenerator("some other generator", nil)
+  x := big.NewInt(0x1234)
+  dleqProofInputs := DLEQProofInputs{X: NewNumber(x), H: NewCurvePoint(H), Context: "verifiable decryption"}
+  marshalledJSON, _ := json.Marshal(dleqProofInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/dleq/generate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  A := new(bn256.G1).ScalarMult(G, x)
+  B := new(bn256.G1).ScalarMult(H, x)
+  if (*res.DLEQProof.A != *NewCurvePoint(A) || *res.DLEQProof.B != *NewCurvePoint(B)) {
+    t.Errorf("Wrong points")
+    return
+  }
+  c, err := NewBigInt(res.DLEQProof.C.V, nil)
+  s, err := NewBigInt(res.DLEQProof.S.V, err)
+  isValid, err := VerifyDLEQ(G, H, A, B, c, s, "verifiable decryption", err)
+  if (err != nil || !isValid) {
+    t.Errorf("Proof does not verify")
+    return
+  }
+}
+
+func TestDLEQProofVerify(t *testing.T) {
+  G, _ := NUMSGenerator("some other generator", nil)
+  H, _ := NUMSGenerator("yet another generator", nil)
+  A, B, c, s, err := ProveDLEQ(G, H, big.NewInt(987654321), "verifiable decryption", nil)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  proof := &DLEQProof{A: NewCurvePoint(A), B: NewCurvePoint(B), C: NewNumber(c), S: NewNumber(s)}
+  other := &DLEQProof{A: NewCurvePoint(A), B: NewCurvePoint(new(bn256.G1).ScalarMult(H, big.NewInt(987654322))), C: NewNumber(c), S: NewNumber(s)}
+  cases := []DLEQProofVerifyInputs{
+    DLEQProofVerifyInputs{G: NewCurvePoint(G), H: NewCurvePoint(H), Context: "verifiable decryption", Proof: proof},
+    DLEQProofVerifyInputs{G: NewCurvePoint(G), H: NewCurvePoint(H), Context: "verifiable decryption", Proof: other},
+    DLEQProofVerifyInputs{G: NewCurvePoint(G), H: NewCurvePoint(H), Context: "another context", Proof: proof},
+  }
+  expected := []string{"true", "false", "false"}
+  for i, dleqProofVerifyInputs := range cases {
+    marshalledJSON, _ := json.Marshal(dleqProofVerifyInputs)
+    response, err := http.Post("http://localhost:" + port + "/proof/dleq/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    if (res.Text != expected[i]) {
+      t.Errorf("Wrong result for case %d", i)
+      return
+    }
+  }
+}
+
+func TestCommitmentOpeningProof(t *testing.T) {
+  commitmentProofInputs := CommitmentProofInputs{Openings: []*Opening{&Opening{V: "0x64", B: "0x0a"}}, Context: "compliance"}
+  marshalledJSON, _ := json.Marshal(commitmentProofInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/commitment/opening/generate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  G, H, _ := PedersenGenerators(nil, nil, nil)
+  C := PedersenCommit(G, H, big.NewInt(100), big.NewInt(10))
+  if (len(res.Commitments) != 1 || *res.Commitments[0] != *NewCurvePoint(C)) {
+    t.Errorf("Wrong commitment")
+    return
+  }
+  R, err := NewECPointFromCurvePoint(res.CommitmentProof.R, nil)
+  sv, err := NewBigInt(res.CommitmentProof.SV, err)
+  sb, err := NewBigInt(res.CommitmentProof.SB, err)
+  isValid, err := VerifyCommitmentOpening(G, H, C, R, sv, sb, "compliance", err)
+  if (err != nil || !isValid) {
+    t.Errorf("Proof does not verify")
+    return
+  }
+  isValid, err = VerifyCommitmentOpening(G, H, PedersenCommit(G, H, big.NewInt(101), big.NewInt(10)), R, sv, sb, "compliance", err)
+  if (err != nil || isValid) {
+    t.Errorf("Proof verifies for another commitment")
+    return
+  }
+}
+
+func TestCommitmentEqualityProof(t *testing.T) {
+  commitmentProofInputs := CommitmentProofInputs{Openings: []*Opening{&Opening{V: "0x64", B: "0x0a"}, &Opening{V: "0x64", B: "0x1234"}}, Context: "compliance"}
+  marshalledJSON, _ := json.Marshal(commitmentProofInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/commitment/equality/generate/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if res.Err != nil && res.Err.Msg != "" {
+    t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+    return
+  }
+  G, H, _ := PedersenGenerators(nil, nil, nil)
+  Cs := []*bn256.G1{PedersenCommit(G, H, big.NewInt(100), big.NewInt(10)), PedersenCommit(G, H, big.NewInt(100), big.NewInt(0x1234))}
+  if (len(res.Commitments) != 2 || *res.Commitments[0] != *NewCurvePoint(Cs[0]) || *res.Commitments[1] != *NewCurvePoint(Cs[1])) {
+    t.Errorf("Wrong commitments")
+    return
+  }
+  R, err := NewECPointFromCurvePoint(res.CommitmentProof.R, nil)
+  sb, err := NewBigInt(res.CommitmentProof.SB, err)
+  isValid, err := VerifyCommitmentRelation("equality", G, H, Cs, R, sb, "compliance", err)
+  if (err != nil || !isValid) {
+    t.Errorf("Proof does not verify")
+    return
+  }
+}
+
+func TestCommitmentSumProof(t *testing.T) {
+  G, H, _ := PedersenGenerators(nil, nil, nil)
+  vs := []*big.Int{big.NewInt(100), big.NewInt(54), big.NewInt(154)}
+  bs := []*big.Int{big.NewInt(10), big.NewInt(11), big.NewInt(5)}
+  Cs, R, sb, err := ProveCommitmentRelation("sum", G, H, vs, bs, "compliance", nil)
+  if err != nil {
+    t.Errorf("An error occurred: %s\n", err)
+    return
+  }
+  proof := &CommitmentProof{R: NewCurvePoint(R), SB: fmt.Sprintf("0x%064x", sb)}
+  commitments := []*CurvePoint{NewCurvePoint(Cs[0]), NewCurvePoint(Cs[1]), NewCurvePoint(Cs[2])}
+  wrong := []*CurvePoint{commitments[0], commitments[1], NewCurvePoint(PedersenCommit(G, H, big.NewInt(155), big.NewInt(5)))}
+  cases := []CommitmentProofVerifyInputs{
+    CommitmentProofVerifyInputs{Commitments: commitments, Context: "compliance", Proof: proof},
+    CommitmentProofVerifyInputs{Commitments: wrong, Context: "compliance", Proof: proof},
+  }
+  expected := []string{"true", "false"}
+  for i, commitmentProofVerifyInputs := range cases {
+    marshalledJSON, _ := json.Marshal(commitmentProofVerifyInputs)
+    response, err := http.Post("http://localhost:" + port + "/proof/commitment/sum/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+    if err != nil {
+      t.Errorf("An error occurred while making request to API: %s\n", err)
+      return
+    }
+    defer response.Body.Close()
+    contents, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+      t.Errorf("An error occurred while reading response body: %s\n", err)
+      return
+    }
+    var res Response
+    err = json.Unmarshal(contents, &res)
+    if err != nil {
+      t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+      return
+    }
+    if res.Err != nil && res.Err.Msg != "" {
+      t.Errorf(fmt.Sprintf("An error occurred: %s\n", res.Err.Msg))
+      return
+    }
+    if (res.Text != expected[i]) {
+      t.Errorf("Wrong result for case %d", i)
+      return
+    }
+  }
+}
+
+func TestCommitmentOpeningProofOffCurve(t *testing.T) {
+  proof := &CommitmentProof{R: &CurvePoint{X: "0x01", Y: "0x02"}, SV: "0x01", SB: "0x01"}
+  commitmentProofVerifyInputs := CommitmentProofVerifyInputs{Commitments: []*CurvePoint{&CurvePoint{X: "0x01", Y: "0x01"}}, Proof: proof}
+  marshalledJSON, _ := json.Marshal(commitmentProofVerifyInputs)
+  response, err := http.Post("http://localhost:" + port + "/proof/commitment/opening/verify/", "application/json", bytes.NewBuffer(marshalledJSON))
+  if err != nil {
+    t.Errorf("An error occurred while making request to API: %s\n", err)
+    return
+  }
+  defer response.Body.Close()
+  contents, err := ioutil.ReadAll(response.Body)
+  if err != nil {
+    t.Errorf("An error occurred while reading response body: %s\n", err)
+    return
+  }
+  var res Response
+  err = json.Unmarshal(contents, &res)
+  if err != nil {
+    t.Errorf("An error occurred while reading into JSON object: %s\n", err)
+    return
+  }
+  if (res.Err == nil || res.Err.Msg == "") {
+    t.Errorf("Commitment off the curve not detected\n")
+    return
+  }
+}
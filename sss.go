@@ -0,0 +1,81 @@
+package main
+
+import (
+  "crypto/rand"
+  "errors"
+  "fmt"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// Shamir secret sharing over Fr: the secret is f(0) for a random polynomial f
+// of degree t-1 and share i is f(i)
+
+// f(1), ..., f(n) together with the coefficients of f
+func SplitSecret(secret *big.Int, t int, n int, err error) ([]*big.Int, []*big.Int, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  if t < 1 || t > n {
+    return nil, nil, errors.New("Threshold t must be between 1 and n")
+  }
+  coeffs := make([]*big.Int, t)
+  coeffs[0] = new(big.Int).Set(secret)
+  for i := 1; i < t; i++ {
+    coeffs[i], err = rand.Int(rand.Reader, bn256.Order)
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  shares := make([]*big.Int, n)
+  for i := range shares {
+    shares[i] = PolyEval(coeffs, big.NewInt(int64(i+1)))
+  }
+  return shares, coeffs, nil
+}
+
+// lambda_i = prod_(j != i) x_j / (x_j - x_i), so that f(0) = sum lambda_i f(x_i)
+func LagrangeCoefficientsAtZero(xs []*big.Int, err error) ([]*big.Int, error) {
+  if err != nil {
+    return nil, err
+  }
+  nums := make([]*big.Int, len(xs))
+  denoms := make([]*big.Int, len(xs))
+  for i := range xs {
+    if IsZero(new(big.Int).Mod(xs[i], bn256.Order)) {
+      return nil, fmt.Errorf("Share index must not be zero (index %d)", i)
+    }
+    nums[i] = big.NewInt(1)
+    denoms[i] = big.NewInt(1)
+    for j := range xs {
+      if i == j {
+        continue
+      }
+      nums[i].Mul(nums[i], xs[j]).Mod(nums[i], bn256.Order)
+      denoms[i].Mul(denoms[i], new(big.Int).Sub(xs[j], xs[i])).Mod(denoms[i], bn256.Order)
+    }
+    if IsZero(denoms[i]) {
+      return nil, fmt.Errorf("Duplicate share index at index %d", i)
+    }
+  }
+  invs, err := BatchInvert(denoms, bn256.Order, err)
+  if err != nil {
+    return nil, err
+  }
+  for i := range nums {
+    nums[i].Mul(nums[i], invs[i]).Mod(nums[i], bn256.Order)
+  }
+  return nums, nil
+}
+
+func CombineShares(xs []*big.Int, ys []*big.Int, err error) (*big.Int, error) {
+  lambdas, err := LagrangeCoefficientsAtZero(xs, err)
+  if err != nil {
+    return nil, err
+  }
+  secret := new(big.Int)
+  for i := range lambdas {
+    secret.Add(secret, new(big.Int).Mul(lambdas[i], ys[i]))
+  }
+  return secret.Mod(secret, bn256.Order), nil
+}
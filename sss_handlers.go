@@ -0,0 +1,81 @@
+package main
+
+import (
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// shares are only ever handed out for indices 1..n, so this also bounds the
+// amount of work per request
+const MaxShares = 1 << 16
+
+func SSSSplit(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var splitInputs SplitInputs
+  err := ReadContentsIntoStruct(r, &splitInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if splitInputs.N > MaxShares {
+    encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Number of shares must be at most %d", MaxShares)}})
+    return
+  }
+  secret, err := NewFieldElement(splitInputs.Secret, bn256.Order, err)
+  values, _, err := SplitSecret(secret, splitInputs.T, splitInputs.N, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  shares := make([]*Share, len(values))
+  for i := range values {
+    shares[i] = &Share{I: i+1, V: fmt.Sprintf("0x%064x", values[i])}
+  }
+  encoder.Encode(Response{Shares: shares})
+}
+
+func SSSCombine(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var combineInputs CombineInputs
+  err := ReadContentsIntoStruct(r, &combineInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if len(combineInputs.Shares) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "At least one share is required"}})
+    return
+  }
+  xs, ys, err := NewShares(combineInputs.Shares, err)
+  secret, err := CombineShares(xs, ys, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Num: NewNumber(secret)})
+}
+
+func NewShares(shares []*Share, err error) ([]*big.Int, []*big.Int, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  xs := make([]*big.Int, len(shares))
+  ys := make([]*big.Int, len(shares))
+  for i, share := range shares {
+    if share == nil {
+      return nil, nil, fmt.Errorf("Missing share at index %d", i)
+    }
+    if share.I < 1 {
+      return nil, nil, fmt.Errorf("Share index must be positive (index %d)", i)
+    }
+    xs[i] = big.NewInt(int64(share.I))
+    ys[i], err = NewFieldElement(share.V, bn256.Order, err)
+  }
+  if err != nil {
+    return nil, nil, err
+  }
+  return xs, ys, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+  "encoding/binary"
+  "math/big"
+  "github.com/rynobey/bn256"
+  "github.com/ethereum/go-ethereum/crypto/sha3"
+)
+
+// Fiat-Shamir transcript for non-interactive proofs: every message is
+// absorbed as state = keccak256(state || len(label) || label || len(data) ||
+// data) with 8 byte big endian lengths, and a challenge is keccak256(state ||
+// "challenge" || label) mod r, which then replaces the state, so every
+// challenge depends on everything sent before it
+
+const TranscriptDST = "ECC-API-TRANSCRIPT-V01"
+
+type Transcript struct {
+  state []byte
+}
+
+func NewTranscript(label string) (*Transcript) {
+  t := &Transcript{state: []byte{}}
+  t.Append(TranscriptDST, []byte(label))
+  return t
+}
+
+func (t *Transcript) Append(label string, data []byte) {
+  h := sha3.NewKeccak256()
+  h.Write(t.state)
+  for _, part := range [][]byte{[]byte(label), data} {
+    length := make([]byte, 8)
+    binary.BigEndian.PutUint64(length, uint64(len(part)))
+    h.Write(length)
+    h.Write(part)
+  }
+  t.state = h.Sum(nil)
+}
+
+func (t *Transcript) AppendPoint(label string, P *bn256.G1) {
+  t.Append(label, P.Marshal())
+}
+
+func (t *Transcript) AppendScalar(label string, s *big.Int) {
+  t.Append(label, RFC6979IntToOctets(new(big.Int).Mod(s, bn256.Order), 32))
+}
+
+func (t *Transcript) AppendInt(label string, n int) {
+  data := make([]byte, 8)
+  binary.BigEndian.PutUint64(data, uint64(n))
+  t.Append(label, data)
+}
+
+// a non-zero challenge in [1, r)
+func (t *Transcript) Challenge(label string) (*big.Int) {
+  for {
+    h := sha3.NewKeccak256()
+    h.Write(t.state)
+    h.Write([]byte("challenge"))
+    h.Write([]byte(label))
+    t.state = h.Sum(nil)
+    c := new(big.Int).Mod(new(big.Int).SetBytes(t.state), bn256.Order)
+    if !IsZero(c) {
+      return c
+    }
+  }
+}
@@ -2,7 +2,6 @@ package main
 
 import (
   "errors"
-  "crypto/rand"
   "net/http"
   "encoding/json"
   "io/ioutil"
@@ -10,9 +9,16 @@ import (
   "github.com/rynobey/bn256"
   "encoding/hex"
   "fmt"
+  "strings"
   "github.com/ethereum/go-ethereum/crypto/sha3"
 )
 
+var ErrCoordinateOutOfRange = errors.New("Coordinates must be in the range [0, p)")
+var ErrCoordinateTooLong = errors.New("Coordinates must be at most 64 hex digits")
+var ErrNotOnCurve = errors.New("Point is not on the curve")
+var ErrKeyInfinity = errors.New("Public key must not be the point at infinity")
+var ErrSignatureInfinity = errors.New("Signature must not be the point at infinity")
+
 func Hex32ByteChunksToStr(hexChunks []string) (string) {
   hexStr := ""
   lenArr := len(hexChunks)
@@ -62,6 +68,11 @@ func ReadContentsIntoStruct(r *http.Request, obj interface{}) (error) {
   return nil
 }
 
+// probabilistic test; negative numbers are never prime
+func IsPrime(num *big.Int) (bool) {
+  return num.Sign() > 0 && num.ProbablyPrime(20)
+}
+
 func AddPrefixIfMissing(num string) (string) {
   var newNum string
   if len(num) < 3 || num[0:2] != "0x" {
@@ -75,6 +86,13 @@ func NewBigInt(num string, err error) (*big.Int, error) {
   if err != nil {
     return nil, err
   } else {
+    if strings.HasPrefix(num, "-") {
+      bn, err := NewBigInt(num[1:], err)
+      if err != nil {
+        return nil, err
+      }
+      return bn.Neg(bn), nil
+    }
     num = AddPrefixIfMissing(num)
     if len(num) < 3 {
       return nil, errors.New("Unable to initialize big.Int from string: too short")
@@ -94,6 +112,10 @@ func NewECPoint(xCoord string, yCoord string, err error) (*bn256.G1, error) {
     P := new(bn256.G1)
     xCoord = AddPrefixIfMissing(xCoord)
     yCoord = AddPrefixIfMissing(yCoord)
+    // %064s only pads, a longer coordinate would shift into the next one
+    if len(xCoord) > 66 || len(yCoord) > 66 {
+      return nil, ErrCoordinateTooLong
+    }
     marshalledPoint := fmt.Sprintf("%064s%064s", xCoord[2:], yCoord[2:])
     marshalledBytes, err := hex.DecodeString(marshalledPoint)
     if err != nil {
@@ -107,37 +129,417 @@ func NewECPoint(xCoord string, yCoord string, err error) (*bn256.G1, error) {
   }
 }
 
+func NewECPointFromCoordinates(x *big.Int, y *big.Int, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  return NewECPoint(fmt.Sprintf("0x%064x", x), fmt.Sprintf("0x%064x", y), nil)
+}
+
+func NewECPointFromCurvePoint(pt *CurvePoint, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  if pt == nil {
+    return nil, errors.New("Missing curve point")
+  }
+  if pt.C != "" {
+    pt, err = DecompressCurvePoint(pt.C, err)
+    if err != nil {
+      return nil, err
+    }
+  }
+  return NewECPoint(pt.X, pt.Y, err)
+}
+
+func NewECPointsFromCurvePoints(pts []*CurvePoint, err error) ([]*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  points := make([]*bn256.G1, len(pts))
+  for i := range pts {
+    points[i], err = NewECPointFromCurvePoint(pts[i], err)
+  }
+  if err != nil {
+    return nil, err
+  }
+  return points, nil
+}
+
+// compressed points are 33 bytes: 0x02 (even y) or 0x03 (odd y) followed by
+// x, or 0x00 followed by 32 zero bytes for the point at infinity
+func CompressCurvePoint(xCoord string, yCoord string, err error) (string, error) {
+  x, err := NewBigInt(xCoord, err)
+  y, err := NewBigInt(yCoord, err)
+  if err != nil {
+    return "", err
+  }
+  if IsZero(x) && IsZero(y) {
+    return fmt.Sprintf("0x%066x", 0), nil
+  }
+  return fmt.Sprintf("0x%02x%064x", 2 + y.Bit(0), x), nil
+}
+
+// rewrites every {"x", "y"} curve point in a decoded JSON response as {"c"};
+// G2 points are left alone since their coordinates are objects
+func CompressCurvePoints(obj interface{}) (interface{}) {
+  switch v := obj.(type) {
+  case map[string]interface{}:
+    x, xOk := v["x"].(string)
+    y, yOk := v["y"].(string)
+    if xOk && yOk && len(v) == 2 {
+      compressed, err := CompressCurvePoint(x, y, nil)
+      if err == nil {
+        return map[string]interface{}{"c": compressed}
+      }
+    }
+    for key, value := range v {
+      v[key] = CompressCurvePoints(value)
+    }
+  case []interface{}:
+    for i, value := range v {
+      v[i] = CompressCurvePoints(value)
+    }
+  }
+  return obj
+}
+
+func DecompressCurvePoint(compressed string, err error) (*CurvePoint, error) {
+  return DecompressCurvePointOn(BN256Params, compressed, err)
+}
+
+func DecompressCurvePointOn(params *CurveParams, compressed string, err error) (*CurvePoint, error) {
+  if err != nil {
+    return nil, err
+  }
+  compressed = AddPrefixIfMissing(compressed)
+  compressedBytes, err := hex.DecodeString(compressed[2:])
+  if err != nil {
+    return nil, err
+  }
+  if len(compressedBytes) != 33 {
+    return nil, errors.New("Compressed points must be 33 bytes long")
+  }
+  x := new(big.Int).SetBytes(compressedBytes[1:])
+  switch compressedBytes[0] {
+  case 0x00:
+    if !IsZero(x) {
+      return nil, errors.New("Malformed compressed point at infinity")
+    }
+    return &CurvePoint{X: fmt.Sprintf("0x%064x", 0), Y: fmt.Sprintf("0x%064x", 0)}, nil
+  case 0x02, 0x03:
+  default:
+    return nil, errors.New("Compressed points must start with 0x00, 0x02 or 0x03")
+  }
+  if x.Cmp(params.P) >= 0 {
+    return nil, ErrCoordinateOutOfRange
+  }
+  y := new(big.Int).ModSqrt(params.RHS(x), params.P)
+  if y == nil {
+    return nil, ErrNotOnCurve
+  }
+  if y.Bit(0) != uint(compressedBytes[0] & 1) {
+    y.Sub(params.P, y).Mod(y, params.P)
+  }
+  return &CurvePoint{X: fmt.Sprintf("0x%064x", x), Y: fmt.Sprintf("0x%064x", y)}, nil
+}
+
+func ValidateCurvePoint(pt *CurvePoint, err error) (*PointVerdict, error) {
+  return ValidateCurvePointOn(BN256Params, pt, err)
+}
+
+func ValidateCurvePointOn(params *CurveParams, pt *CurvePoint, err error) (*PointVerdict, error) {
+  if err != nil {
+    return nil, err
+  }
+  if pt.C != "" {
+    decompressed, err := DecompressCurvePointOn(params, pt.C, nil)
+    if err == ErrCoordinateOutOfRange {
+      return &PointVerdict{Valid: false, Reason: "out_of_range", Msg: err.Error()}, nil
+    }
+    if err == ErrNotOnCurve {
+      return &PointVerdict{Valid: false, Reason: "not_on_curve", Msg: err.Error()}, nil
+    }
+    if err != nil {
+      return nil, err
+    }
+    pt = decompressed
+  }
+  x, err := NewBigInt(pt.X, err)
+  y, err := NewBigInt(pt.Y, err)
+  if err != nil {
+    return nil, err
+  }
+  if x.Sign() < 0 || x.Cmp(params.P) >= 0 || y.Sign() < 0 || y.Cmp(params.P) >= 0 {
+    return &PointVerdict{Valid: false, Reason: "out_of_range", Msg: ErrCoordinateOutOfRange.Error()}, nil
+  }
+  if IsZero(x) && IsZero(y) {
+    return &PointVerdict{Valid: false, Reason: "infinity", Msg: "Point is the point at infinity"}, nil
+  }
+  // y^2 == x^3 + a*x + b
+  lhs := new(big.Int).Exp(y, big.NewInt(2), params.P)
+  if lhs.Cmp(params.RHS(x)) != 0 {
+    return &PointVerdict{Valid: false, Reason: "not_on_curve", Msg: ErrNotOnCurve.Error()}, nil
+  }
+  return &PointVerdict{Valid: true}, nil
+}
+
+func IsInfinity(P *bn256.G1) (bool) {
+  for _, b := range P.Marshal() {
+    if b != 0 {
+      return false
+    }
+  }
+  return true
+}
+
+func IsInfinityG2(P *bn256.G2) (bool) {
+  for _, b := range P.Marshal() {
+    if b != 0 {
+      return false
+    }
+  }
+  return true
+}
+
+func NewECPointG2(pt *G2Point, err error) (*bn256.G2, error) {
+  if err != nil {
+    return nil, err
+  } else {
+    if pt == nil || pt.X == nil || pt.Y == nil {
+      return nil, errors.New("Missing G2 point coordinates")
+    }
+    P := new(bn256.G2)
+    marshalledPoint := ""
+    for _, coord := range []string{pt.X.Im, pt.X.Re, pt.Y.Im, pt.Y.Re} {
+      coord = AddPrefixIfMissing(coord)
+      if len(coord) > 66 {
+        return nil, ErrCoordinateTooLong
+      }
+      marshalledPoint = fmt.Sprintf("%s%064s", marshalledPoint, coord[2:])
+    }
+    marshalledBytes, err := hex.DecodeString(marshalledPoint)
+    if err != nil {
+      return nil, err
+    }
+    _, err = P.Unmarshal(marshalledBytes)
+    if err != nil {
+      return nil, err
+    }
+    return P, nil
+  }
+}
+
+func NewPairingPoints(pairingCheckInputs PairingCheckInputs, err error) ([]*bn256.G1, []*bn256.G2, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  if len(pairingCheckInputs.Pairs) > 0 && pairingCheckInputs.Input != "" {
+    return nil, nil, errors.New("Provide either pairs or input, not both")
+  }
+  if len(pairingCheckInputs.Pairs) > 0 {
+    A := make([]*bn256.G1, len(pairingCheckInputs.Pairs))
+    B := make([]*bn256.G2, len(pairingCheckInputs.Pairs))
+    for i, pair := range pairingCheckInputs.Pairs {
+      if pair == nil {
+        return nil, nil, fmt.Errorf("Missing pair %d", i)
+      }
+      A[i], err = NewECPointFromCurvePoint(pair.A, err)
+      B[i], err = NewECPointG2(pair.B, err)
+      if err != nil {
+        return nil, nil, err
+      }
+    }
+    return A, B, nil
+  }
+  input := AddPrefixIfMissing(pairingCheckInputs.Input)
+  inputBytes, err := hex.DecodeString(input[2:])
+  if err != nil {
+    return nil, nil, err
+  }
+  if len(inputBytes) % 192 != 0 {
+    return nil, nil, errors.New("Pairing input length must be a multiple of 192 bytes")
+  }
+  k := len(inputBytes)/192
+  A := make([]*bn256.G1, k)
+  B := make([]*bn256.G2, k)
+  for i := 0; i < k; i++ {
+    A[i] = new(bn256.G1)
+    B[i] = new(bn256.G2)
+    _, err = A[i].Unmarshal(inputBytes[192*i:192*i+64])
+    if err != nil {
+      return nil, nil, err
+    }
+    _, err = B[i].Unmarshal(inputBytes[192*i+64:192*(i+1)])
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  return A, B, nil
+}
+
+// e = keccak256(M || P.X || P.Y || kG.X || kG.Y), left unreduced
+func SchnorrChallenge(M string, P Point, kG Point) (*big.Int) {
+  P_point := P.CurvePoint()
+  kG_point := kG.CurvePoint()
+  h := sha3.NewKeccak256()
+  h.Reset()
+  h.Write([]byte(fmt.Sprintf("%s%s%s%s%s", M, P_point.X, P_point.Y, kG_point.X, kG_point.Y)))
+  e, _ := new(big.Int).SetString(fmt.Sprintf("%x", h.Sum(nil)), 16)
+  return e
+}
+
+func GenerateCurveSchnorrSignature(curve Curve, M string, X *big.Int, nonceMode string, scheme string, err error) (Point, Point, string, *big.Int, *big.Int, error) {
+  if err != nil {
+    return nil, nil, "", nil, nil, err
+  }
+  err = RequireConstantTime(curve)
+  if err != nil {
+    return nil, nil, "", nil, nil, err
+  }
+  N := curve.Params().N
+  P := curve.BaseMul(X)
+  if scheme == "" {
+    scheme = "legacy"
+  }
+  k, err := SchnorrNonce(N, X, M, nonceMode, curve.Params().Name + "/" + scheme)
+  if err != nil {
+    return nil, nil, "", nil, nil, err
+  }
+  kG := curve.BaseMul(k)
+  e, err := SchnorrChallengeWithScheme(curve, scheme, M, P, kG)
+  if err != nil {
+    return nil, nil, "", nil, nil, err
+  }
+  s := new(big.Int).Mod(new(big.Int).Add(k, new(big.Int).Mul(e, X)), N)
+  return P, kG, M, e, s, nil
+}
+
+func VerifyCurveSchnorrSignature(curve Curve, P Point, M string, E, S *big.Int, err error) (bool, error) {
+  return VerifyCurveSchnorrSignatureWithScheme(curve, "legacy", P, M, E, S, err)
+}
+
+func VerifyCurveSchnorrSignatureWithScheme(curve Curve, scheme string, P Point, M string, E, S *big.Int, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  sG := curve.BaseMul(S)
+  eP := P.Mul(E)
+  kG := sG.Add(eP.Neg())
+  e, err := SchnorrChallengeWithScheme(curve, scheme, M, P, kG)
+  if err != nil {
+    return false, err
+  }
+  return (e.Cmp(E) == 0), nil
+}
+
 func GenerateSchnorrSignature(M string, X *big.Int, err error) (*bn256.G1, *bn256.G1, string, *big.Int, *big.Int, error) {
+  P, kG, M, e, s, err := GenerateCurveSchnorrSignature(BN256, M, X, "random", "legacy", err)
   if err != nil {
     return nil, nil, "", nil, nil, err
-  } else {
-    P := new(bn256.G1).ScalarBaseMult(X)
-    P_point := NewCurvePoint(P)
-    k, _ := rand.Int(rand.Reader, bn256.Order)
-    kG := new(bn256.G1).ScalarBaseMult(k)
-    kG_point := NewCurvePoint(kG)
-    h := sha3.NewKeccak256()
-    h.Reset()
-    h.Write([]byte(fmt.Sprintf("%s%s%s%s%s", M, P_point.X, P_point.Y, kG_point.X, kG_point.Y)))
-    e, _ := new(big.Int).SetString(fmt.Sprintf("%x", h.Sum(nil)), 16)
-    s := new(big.Int).Mod(new(big.Int).Add(k, new(big.Int).Mul(e, X)), bn256.Order)
-    return P, kG, M, e, s, nil
   }
+  return P.(*BN256Point).G1, kG.(*BN256Point).G1, M, e, s, nil
 }
 
 func VerifySchnorrSignature(P *bn256.G1, M string, E, S *big.Int, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  return VerifyCurveSchnorrSignature(BN256, &BN256Point{G1: P}, M, E, S, err)
+}
+
+func GenerateBLSSignature(M string, X *big.Int, err error) (*bn256.G2, *bn256.G1, error) {
+  if err != nil {
+    return nil, nil, err
+  } else {
+    if IsZero(new(big.Int).Mod(X, bn256.Order)) {
+      return nil, nil, errors.New("Private key must be non-zero mod the group order")
+    }
+    H := new(bn256.G1).Hash(M)
+    if H == nil {
+      return nil, nil, errors.New("Failed to hash message to a curve point")
+    }
+    P := new(bn256.G2).ScalarBaseMult(X)
+    S := new(bn256.G1).ScalarMult(H, X)
+    return P, S, nil
+  }
+}
+
+func VerifyBLSSignature(P *bn256.G2, M string, S *bn256.G1, err error) (bool, error) {
+  return VerifyAggregateBLSSignature([]*bn256.G2{P}, []string{M}, S, err)
+}
+
+// a proof of possession signs the key itself, hashed with its own domain so
+// that no ordinary BLS signature can be passed off as one
+const BLSPoPDST = "ECC-API-BLS-POP-V01-with-BN254G1_XMD:SHA-256_SVDW_RO_"
+
+func GenerateBLSPossessionProof(X *big.Int, err error) (*bn256.G2, *bn256.G1, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  if IsZero(new(big.Int).Mod(X, bn256.Order)) {
+    return nil, nil, errors.New("Private key must be non-zero mod the group order")
+  }
+  P := new(bn256.G2).ScalarBaseMult(X)
+  H, err := HashToCurve(P.Marshal(), []byte(BLSPoPDST))
+  if err != nil {
+    return nil, nil, err
+  }
+  return P, new(bn256.G1).ScalarMult(H, X), nil
+}
+
+// e(S, g2) == e(HashToCurve(P), P)
+func VerifyBLSPossessionProof(P *bn256.G2, S *bn256.G1, err error) (bool, error) {
+  if err != nil {
+    return false, err
+  }
+  if IsInfinityG2(P) {
+    return false, ErrKeyInfinity
+  }
+  if IsInfinity(S) {
+    return false, ErrSignatureInfinity
+  }
+  H, err := HashToCurve(P.Marshal(), []byte(BLSPoPDST))
+  if err != nil {
+    return false, err
+  }
+  A := []*bn256.G1{S, new(bn256.G1).Neg(H)}
+  B := []*bn256.G2{new(bn256.G2).ScalarBaseMult(big.NewInt(1)), P}
+  return bn256.PairingCheck(A, B), nil
+}
+
+// checks e(S, g2) == e(H(m_1), P_1) * ... * e(H(m_n), P_n); the messages must
+// be distinct, otherwise aggregation is open to rogue key attacks. keys and
+// the signature at infinity are rejected, as in the KeyValidate of the IETF
+// BLS draft, since they make the check hold for any message
+func VerifyAggregateBLSSignature(Ps []*bn256.G2, Ms []string, S *bn256.G1, err error) (bool, error) {
   if err != nil {
     return false, err
   } else {
-    P_point := NewCurvePoint(P)
-    sG := new(bn256.G1).ScalarBaseMult(S)
-    eP := new(bn256.G1).ScalarMult(P, E)
-    kG := new(bn256.G1).Add(sG, eP.Neg(eP))
-    kG_point := NewCurvePoint(kG)
-    h := sha3.NewKeccak256()
-    h.Reset()
-    h.Write([]byte(fmt.Sprintf("%s%s%s%s%s", M, P_point.X, P_point.Y, kG_point.X, kG_point.Y)))
-    e, _ := new(big.Int).SetString(fmt.Sprintf("%x", h.Sum(nil)), 16)
-    return (e.Cmp(E) == 0), nil
+    if len(Ps) == 0 || len(Ps) != len(Ms) {
+      return false, errors.New("Need one message per public key")
+    }
+    if IsInfinity(S) {
+      return false, ErrSignatureInfinity
+    }
+    seen := make(map[string]bool)
+    A := []*bn256.G1{S}
+    B := []*bn256.G2{new(bn256.G2).ScalarBaseMult(new(big.Int).SetInt64(1))}
+    for i, M := range Ms {
+      if seen[M] {
+        return false, errors.New("Aggregate verification requires distinct messages")
+      }
+      seen[M] = true
+      if IsInfinityG2(Ps[i]) {
+        return false, ErrKeyInfinity
+      }
+      H := new(bn256.G1).Hash(M)
+      if H == nil {
+        return false, errors.New("Failed to hash message to a curve point")
+      }
+      A = append(A, H.Neg(H))
+      B = append(B, Ps[i])
+    }
+    return bn256.PairingCheck(A, B), nil
   }
 }
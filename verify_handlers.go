@@ -1,20 +1,28 @@
 package main
 
 import (
+  "bytes"
   "fmt"
   "net/http"
   "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
 )
 
 func VerifySchnorr(w http.ResponseWriter, r *http.Request) {
   encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
   var schnorrSignature SchnorrSignature
-  err := ReadContentsIntoStruct(r, &schnorrSignature)
+  err = ReadContentsIntoStruct(r, &schnorrSignature)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
-  P, err := NewECPoint(schnorrSignature.P.X, schnorrSignature.P.Y, err)
+  P, err := curve.NewPoint(schnorrSignature.P, err)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
@@ -22,10 +30,143 @@ func VerifySchnorr(w http.ResponseWriter, r *http.Request) {
   M := schnorrSignature.M
   E, err := NewBigInt(schnorrSignature.E, err)
   S, err := NewBigInt(schnorrSignature.S, err)
-  isValid, err := VerifySchnorrSignature(P, M, E, S, err)
+  isValid, err := VerifyCurveSchnorrSignatureWithScheme(curve, schnorrSignature.Scheme, P, M, E, S, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}
+
+func VerifyBLS(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var blsSignature BLSSignature
+  err := ReadContentsIntoStruct(r, &blsSignature)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  P, err := NewECPointG2(blsSignature.P, err)
+  S, err := NewECPointFromCurvePoint(blsSignature.S, err)
+  isValid, err := VerifyBLSSignature(P, blsSignature.M, S, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}
+
+func VerifyBLSPoP(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var blsPoP BLSPoP
+  err := ReadContentsIntoStruct(r, &blsPoP)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  P, err := NewECPointG2(blsPoP.P, err)
+  S, err := NewECPointFromCurvePoint(blsPoP.S, err)
+  isValid, err := VerifyBLSPossessionProof(P, S, err)
   if err != nil {
     encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
     return
   }
   encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
 }
+
+func VerifyAggregateBLS(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var aggregateBLSSignature AggregateBLSSignature
+  err := ReadContentsIntoStruct(r, &aggregateBLSSignature)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  S, err := NewECPointFromCurvePoint(aggregateBLSSignature.S, err)
+  Ps := make([]*bn256.G2, len(aggregateBLSSignature.P))
+  for i, key := range aggregateBLSSignature.P {
+    Ps[i], err = NewECPointG2(key, err)
+  }
+  isValid, err := VerifyAggregateBLSSignature(Ps, aggregateBLSSignature.M, S, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}
+
+func VerifySchnorrBatch(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  curve, err := CurveFromRequest(r)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  var schnorrSignatures []*SchnorrSignature
+  err = ReadContentsIntoStruct(r, &schnorrSignatures)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  n := len(schnorrSignatures)
+  Ps := make([]Point, n)
+  Ms := make([]string, n)
+  Es := make([]*big.Int, n)
+  Ss := make([]*big.Int, n)
+  Rs := make([]Point, n)
+  schemes := make([]string, n)
+  for i, sig := range schnorrSignatures {
+    if sig == nil {
+      err = fmt.Errorf("Missing signature at index %d", i)
+      break
+    }
+    Ps[i], err = curve.NewPoint(sig.P, err)
+    Ms[i] = sig.M
+    schemes[i] = sig.Scheme
+    if err == nil {
+      err = CheckSchnorrChallengeScheme(sig.Scheme)
+    }
+    Es[i], err = NewBigInt(sig.E, err)
+    Ss[i], err = NewBigInt(sig.S, err)
+    if sig.K != nil {
+      Rs[i], err = curve.NewPoint(sig.K, err)
+    }
+    if err != nil {
+      err = fmt.Errorf("Signature at index %d: %s", i, err)
+      break
+    }
+  }
+  allValid, results, err := BatchVerifyCurveSchnorrSignatures(curve, schemes, Ps, Ms, Es, Ss, Rs, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", allValid), Valid: results})
+}
+
+func VerifyCommitment(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var commitmentOpening CommitmentOpening
+  err := ReadContentsIntoStruct(r, &commitmentOpening)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if len(commitmentOpening.V) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "At least one value is required"}})
+    return
+  }
+  C, err := NewECPointFromCurvePoint(commitmentOpening.C, err)
+  vs := make([]*big.Int, len(commitmentOpening.V))
+  for i := range vs {
+    vs[i], err = NewBigInt(commitmentOpening.V[i], err)
+  }
+  b, err := NewBigInt(commitmentOpening.B, err)
+  Gs, H, err := VectorPedersenGenerators(commitmentOpening.Gs, commitmentOpening.H, len(vs), err)
+  expected, err := VectorPedersenCommit(Gs, H, vs, b, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", bytes.Equal(C.Marshal(), expected.Marshal()))})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+  "crypto/rand"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+// verifiable secret sharing: the dealer publishes commitments C_j to the
+// coefficients of the sharing polynomial, and share i is checked against
+// sum_j i^j * C_j. Feldman commits with C_j = a_j*G, Pedersen hides the
+// coefficients with a second random polynomial, C_j = a_j*G + b_j*H, and
+// hands out f(i) together with the blinding share f'(i)
+
+func FeldmanCommitments(G *bn256.G1, coeffs []*big.Int) ([]*bn256.G1) {
+  commitments := make([]*bn256.G1, len(coeffs))
+  for j := range coeffs {
+    commitments[j] = new(bn256.G1).ScalarMult(G, coeffs[j])
+  }
+  return commitments
+}
+
+// the blinding shares and the commitments for the given coefficients
+func PedersenVSSCommitments(G *bn256.G1, H *bn256.G1, coeffs []*big.Int, n int, err error) ([]*big.Int, []*bn256.G1, error) {
+  if err != nil {
+    return nil, nil, err
+  }
+  blinding := make([]*big.Int, len(coeffs))
+  for j := range blinding {
+    blinding[j], err = rand.Int(rand.Reader, bn256.Order)
+    if err != nil {
+      return nil, nil, err
+    }
+  }
+  blindingShares := make([]*big.Int, n)
+  for i := range blindingShares {
+    blindingShares[i] = PolyEval(blinding, big.NewInt(int64(i+1)))
+  }
+  commitments := make([]*bn256.G1, len(coeffs))
+  for j := range coeffs {
+    commitments[j] = PedersenCommit(G, H, coeffs[j], blinding[j])
+  }
+  return blindingShares, commitments, nil
+}
+
+// sum_j i^j * C_j, the commitment to share i
+func ShareCommitment(i *big.Int, commitments []*bn256.G1, err error) (*bn256.G1, error) {
+  if err != nil {
+    return nil, err
+  }
+  powers := make([]*big.Int, len(commitments))
+  power := big.NewInt(1)
+  for j := range powers {
+    powers[j] = new(big.Int).Set(power)
+    power.Mul(power, i).Mod(power, bn256.Order)
+  }
+  return MultiScalarMult(powers, commitments, err)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+  "bytes"
+  "fmt"
+  "net/http"
+  "encoding/json"
+  "math/big"
+  "github.com/rynobey/bn256"
+)
+
+func VSSSplit(pedersen bool) (http.HandlerFunc) {
+  return func(w http.ResponseWriter, r *http.Request) {
+    encoder := json.NewEncoder(w)
+    var vssSplitInputs VSSSplitInputs
+    err := ReadContentsIntoStruct(r, &vssSplitInputs)
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    if vssSplitInputs.N > MaxShares {
+      encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Number of shares must be at most %d", MaxShares)}})
+      return
+    }
+    G, H, err := PedersenGenerators(vssSplitInputs.G, vssSplitInputs.H, err)
+    secret, err := NewFieldElement(vssSplitInputs.Secret, bn256.Order, err)
+    values, coeffs, err := SplitSecret(secret, vssSplitInputs.T, vssSplitInputs.N, err)
+    var blindingShares []*big.Int
+    var commitments []*bn256.G1
+    if pedersen {
+      blindingShares, commitments, err = PedersenVSSCommitments(G, H, coeffs, len(values), err)
+    } else {
+      commitments = FeldmanCommitments(G, coeffs)
+    }
+    if err != nil {
+      encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+      return
+    }
+    shares := make([]*Share, len(values))
+    for i := range values {
+      shares[i] = &Share{I: i+1, V: fmt.Sprintf("0x%064x", values[i])}
+      if pedersen {
+        shares[i].B = fmt.Sprintf("0x%064x", blindingShares[i])
+      }
+    }
+    curvePoints := make([]*CurvePoint, len(commitments))
+    for j := range commitments {
+      curvePoints[j] = NewCurvePoint(commitments[j])
+    }
+    encoder.Encode(Response{Shares: shares, Commitments: curvePoints})
+  }
+}
+
+func VSSVerify(w http.ResponseWriter, r *http.Request) {
+  encoder := json.NewEncoder(w)
+  var vssVerifyInputs VSSVerifyInputs
+  err := ReadContentsIntoStruct(r, &vssVerifyInputs)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  if vssVerifyInputs.Scheme != "feldman" && vssVerifyInputs.Scheme != "pedersen" {
+    encoder.Encode(Response{Err: &Error{Msg: fmt.Sprintf("Unknown VSS scheme: %s", vssVerifyInputs.Scheme)}})
+    return
+  }
+  if vssVerifyInputs.Share == nil || len(vssVerifyInputs.Commitments) == 0 {
+    encoder.Encode(Response{Err: &Error{Msg: "A share and at least one commitment are required"}})
+    return
+  }
+  G, H, err := PedersenGenerators(vssVerifyInputs.G, vssVerifyInputs.H, err)
+  xs, ys, err := NewShares([]*Share{vssVerifyInputs.Share}, err)
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  commitments := make([]*bn256.G1, len(vssVerifyInputs.Commitments))
+  for j := range commitments {
+    commitments[j], err = NewECPointFromCurvePoint(vssVerifyInputs.Commitments[j], err)
+  }
+  expected, err := ShareCommitment(xs[0], commitments, err)
+  b := new(big.Int)
+  if vssVerifyInputs.Scheme == "pedersen" {
+    b, err = NewFieldElement(vssVerifyInputs.Share.B, bn256.Order, err)
+  }
+  if err != nil {
+    encoder.Encode(Response{Err: &Error{Msg: err.Error()}})
+    return
+  }
+  // a Feldman share is a Pedersen share with no blinding
+  actual := PedersenCommit(G, H, ys[0], b)
+  isValid := bytes.Equal(actual.Marshal(), expected.Marshal())
+  encoder.Encode(Response{Text: fmt.Sprintf("%t", isValid)})
+}